@@ -3,6 +3,7 @@ package main
 import (
 	"os"
 
+	"git-genius/internal/cli"
 	"git-genius/internal/menu"
 	"git-genius/internal/system"
 	"git-genius/internal/ui"
@@ -15,6 +16,25 @@ func init() {
 }
 
 func main() {
+	// --- Unattended runs: canned answers, one per line ---
+	if path := os.Getenv("GIT_GENIUS_ANSWERS"); path != "" {
+		answers, err := ui.LoadScriptedPrompter(path)
+		if err != nil {
+			ui.Error("Cannot read answers file: " + path)
+			os.Exit(cli.ExitUsage)
+		}
+		answers.OnExhausted = func() {
+			ui.Error("Scripted answers exhausted")
+			os.Exit(cli.ExitFailed)
+		}
+		ui.SetPrompter(answers)
+	}
+
+	// --- Non-interactive subcommands (scripts, aliases, editor tasks) ---
+	if len(os.Args) > 1 {
+		os.Exit(cli.Run(os.Args[1:]))
+	}
+
 	ui.Clear()
 	ui.Header("Git Genius")
 
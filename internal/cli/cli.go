@@ -0,0 +1,461 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"git-genius/internal/doctor"
+	"git-genius/internal/gitops"
+	"git-genius/internal/menu"
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+/*
+Non-interactive entry point
+
+Usage:
+  git-genius                      → interactive menu
+  git-genius <command> [flags]    → run a single operation and exit
+
+Exit codes:
+  0 = success
+  1 = operation failed
+  2 = invalid usage
+*/
+
+const Version = "1.0"
+
+const (
+	ExitOK     = 0
+	ExitFailed = 1
+	ExitUsage  = 2
+)
+
+// assumeYes is set by the --yes flag
+var assumeYes = false
+
+type command struct {
+	name     string
+	args     string
+	summary  string
+	needsGit bool
+	run      func(args []string) int
+}
+
+var commands []command
+
+func init() {
+	commands = []command{
+		{"push", "[-m message] [--yes] [paths...]", "Commit changes (all or paths) and push", true, cmdPush},
+		{"pull", "", "Pull latest changes for current branch", true, cmdPull},
+		{"smart-pull", "[--yes]", "Auto-stash, pull, restore changes", true, cmdSmartPull},
+		{"fetch", "", "Fetch all remotes", true, cmdFetch},
+		{"status", "", "Show git status", true, cmdStatus},
+		{"switch", "[--stash|--carry] <branch>", "Switch to (or create) a branch", true, cmdSwitch},
+		{"merge", "[--no-ff] <branch>", "Merge a branch into the current one", true, cmdMerge},
+		{"log", "[-n N] [filters] [path]", "Show history with a branch graph", true, cmdLog},
+		{"diff", "[--staged] [--from REV] [paths...]", "Show changes with word highlighting", true, cmdDiff},
+		{"changelog", "[--from T] [--to T] [--write]", "Markdown changelog grouped by commit type", true, cmdChangelog},
+		{"stash", "save [-m msg] [-u|-a] | list | pop", "Manage stashes", true, cmdStash},
+		{"undo", "[--yes]", "Undo last commit (changes kept)", true, cmdUndo},
+		{"doctor", "[--yes]", "Run health check", false, cmdDoctor},
+		{"version", "", "Print version", false, cmdVersion},
+		{"help", "", "Show this help", false, cmdHelp},
+	}
+}
+
+// Run executes a subcommand and returns the process exit code
+func Run(args []string) int {
+	if len(args) == 0 {
+		return usage(os.Stderr, ExitUsage)
+	}
+
+	name := args[0]
+	switch name {
+	case "-h", "--help":
+		name = "help"
+	case "-v", "--version":
+		name = "version"
+	}
+
+	for _, c := range commands {
+		if c.name != name {
+			continue
+		}
+
+		if c.needsGit && !system.CommandExists("git") {
+			ui.Error("Git is required for: " + c.name)
+			return ExitFailed
+		}
+		return c.run(args[1:])
+	}
+
+	ui.Error("Unknown command: " + name)
+	return usage(os.Stderr, ExitUsage)
+}
+
+/* ============================================================
+   COMMANDS
+   ============================================================ */
+
+func cmdPush(args []string) int {
+	fs := newFlags("push")
+	msg := fs.String("m", "", "commit message")
+	allowSecrets := fs.Bool("allow-secrets", false, "skip the secret scan")
+	allowLarge := fs.Bool("allow-large", false, "commit files over the size limit")
+	if !parse(fs, args) {
+		return ExitUsage
+	}
+
+	// Optional paths limit what gets committed (default: everything)
+	opts := gitops.PushOptions{Message: *msg, AllowSecrets: *allowSecrets, AllowLargeFiles: *allowLarge}
+	if fs.NArg() > 0 {
+		opts.Paths = fs.Args()
+	}
+	return runOp("push", func() (gitops.Result, error) { return menu.PushFlow(opts) })
+}
+
+func cmdPull(args []string) int {
+	if !parse(newFlags("pull"), args) {
+		return ExitUsage
+	}
+	return runOp("pull", gitops.Pull)
+}
+
+func cmdSmartPull(args []string) int {
+	if !parse(newFlags("smart-pull"), args) {
+		return ExitUsage
+	}
+	return runOp("smart-pull", menu.SmartPullFlow)
+}
+
+func cmdFetch(args []string) int {
+	if !parse(newFlags("fetch"), args) {
+		return ExitUsage
+	}
+	return runOp("fetch", gitops.Fetch)
+}
+
+func cmdStatus(args []string) int {
+	if !parse(newFlags("status"), args) {
+		return ExitUsage
+	}
+	return runOp("status", gitops.Status)
+}
+
+func cmdSwitch(args []string) int {
+	fs := newFlags("switch")
+	stash := fs.Bool("stash", false, "stash uncommitted changes first")
+	carry := fs.Bool("carry", false, "take uncommitted changes along")
+	if !parse(fs, args) {
+		return ExitUsage
+	}
+	if fs.NArg() != 1 || (*stash && *carry) {
+		ui.Error("Usage: git-genius switch [--stash|--carry] <branch>")
+		return ExitUsage
+	}
+
+	dirty := gitops.DirtyRefuse
+	if *stash {
+		dirty = gitops.DirtyStash
+	} else if *carry {
+		dirty = gitops.DirtyCarry
+	}
+	return runOp("switch", func() (gitops.Result, error) { return menu.SwitchFlow(fs.Arg(0), dirty) })
+}
+
+func cmdStash(args []string) int {
+	if len(args) == 0 {
+		ui.Error("Usage: git-genius stash save [-m message] [-u|-a] [--keep-index] [paths...] | list | pop")
+		return ExitUsage
+	}
+
+	fs := newFlags("stash " + args[0])
+	var opts gitops.StashOptions
+	fs.StringVar(&opts.Message, "m", "", "stash message")
+	fs.BoolVar(&opts.Untracked, "u", false, "include untracked files")
+	fs.BoolVar(&opts.Ignored, "a", false, "include untracked and ignored files")
+	fs.BoolVar(&opts.KeepIndex, "keep-index", false, "leave staged changes in the work tree")
+	if !parse(fs, args[1:]) {
+		return ExitUsage
+	}
+
+	switch args[0] {
+	case "save", "push":
+		if fs.NArg() > 0 {
+			opts.Paths = fs.Args()
+		}
+		return runOp("stash-save", func() (gitops.Result, error) { return gitops.StashSave(opts) })
+	case "list":
+		return runOp("stash-list", gitops.StashList)
+	case "pop":
+		return runOp("stash-pop", gitops.StashPop)
+	}
+
+	ui.Error("Unknown stash action: " + args[0])
+	return ExitUsage
+}
+
+func cmdMerge(args []string) int {
+	fs := newFlags("merge")
+	noFF := fs.Bool("no-ff", false, "always create a merge commit")
+	if !parse(fs, args) {
+		return ExitUsage
+	}
+	if fs.NArg() != 1 {
+		ui.Error("Usage: git-genius merge [--no-ff] <branch>")
+		return ExitUsage
+	}
+	return runOp("merge", func() (gitops.Result, error) { return gitops.Merge(fs.Arg(0), *noFF) })
+}
+
+func cmdLog(args []string) int {
+	fs := newFlags("log")
+	limit := fs.Int("n", 20, "number of commits")
+	var filter gitops.LogFilter
+	fs.StringVar(&filter.Author, "author", "", "only commits by this author")
+	fs.StringVar(&filter.Grep, "grep", "", "only commits whose message contains this text")
+	fs.StringVar(&filter.Since, "since", "", "only commits after this date")
+	fs.StringVar(&filter.Until, "until", "", "only commits before this date")
+	fs.BoolVar(&filter.All, "all", false, "include every branch")
+	if !parse(fs, args) {
+		return ExitUsage
+	}
+	if fs.NArg() > 1 || *limit < 1 {
+		ui.Error("Usage: git-genius log [-n N] [--author A] [--grep T] [--since D] [--until D] [--all] [path]")
+		return ExitUsage
+	}
+	filter.Path = fs.Arg(0)
+
+	return runOp("log", func() (gitops.Result, error) { return gitops.Log(filter, 0, *limit) })
+}
+
+func cmdDiff(args []string) int {
+	fs := newFlags("diff")
+	staged := fs.Bool("staged", false, "staged changes")
+	all := fs.Bool("all", false, "everything uncommitted (staged + not staged)")
+	from := fs.String("from", "", "compare from this commit, branch or tag")
+	to := fs.String("to", "", "compare to this revision (default: work tree)")
+	sideBySide := fs.Bool("side-by-side", false, "two columns (wide terminals)")
+	if !parse(fs, args) {
+		return ExitUsage
+	}
+
+	req := gitops.DiffRequest{Source: gitops.DiffWorktree, Paths: fs.Args()}
+	switch {
+	case *from != "":
+		req.Source, req.From, req.To = gitops.DiffCommits, *from, *to
+	case *to != "" || (*staged && *all):
+		ui.Error("Usage: git-genius diff [--staged|--all] [--from REV [--to REV]] [--side-by-side] [paths...]")
+		return ExitUsage
+	case *staged:
+		req.Source = gitops.DiffStaged
+	case *all:
+		req.Source = gitops.DiffHead
+	}
+
+	menu.SetDiffLayout(*sideBySide)
+	return runOp("diff", func() (gitops.Result, error) { return gitops.Diff(req) })
+}
+
+func cmdChangelog(args []string) int {
+	fs := newFlags("changelog")
+	from := fs.String("from", "", "start after this tag or commit (default: previous version tag)")
+	to := fs.String("to", "HEAD", "end at this tag or commit")
+	title := fs.String("title", "", "section heading (default: the tag, or Unreleased)")
+	write := fs.Bool("write", false, "prepend the section to "+gitops.ChangelogFile)
+	commit := fs.Bool("commit", false, "commit "+gitops.ChangelogFile+" (with --write)")
+	if !parse(fs, args) {
+		return ExitUsage
+	}
+	if fs.NArg() > 0 || (*commit && !*write) {
+		ui.Error("Usage: git-genius changelog [--from TAG] [--to REV] [--title T] [--write [--commit]]")
+		return ExitUsage
+	}
+
+	if !*write {
+		return runOp("changelog", func() (gitops.Result, error) { return gitops.GenerateChangelog(*from, *to, *title) })
+	}
+	return runOp("update-changelog", func() (gitops.Result, error) {
+		cl, err := gitops.BuildChangelog(*from, *to, *title)
+		if err != nil {
+			return gitops.Result{Operation: "update-changelog"}, err
+		}
+		return gitops.PrependChangelog(cl, *commit)
+	})
+}
+
+func cmdUndo(args []string) int {
+	if !parse(newFlags("undo"), args) {
+		return ExitUsage
+	}
+	return runOp("undo", menu.UndoFlow)
+}
+
+func cmdDoctor(args []string) int {
+	if !parse(newFlags("doctor"), args) {
+		return ExitUsage
+	}
+
+	checks, err := doctor.Run()
+
+	res := gitops.Result{Operation: "doctor", Data: checks}
+	for _, c := range checks {
+		if c.Status == doctor.StatusWarn {
+			res.Warnings = append(res.Warnings, c.Message)
+		}
+	}
+
+	// Doctor already printed every check
+	if !jsonOutput {
+		return exitCode(err)
+	}
+	return finish(res, err)
+}
+
+func cmdVersion(args []string) int {
+	fmt.Println("git-genius " + Version)
+	return ExitOK
+}
+
+func cmdHelp(args []string) int {
+	return usage(os.Stdout, ExitOK)
+}
+
+/* ============================================================
+   HELPERS
+   ============================================================ */
+
+// newFlags creates a flag set with the flags shared by every command
+func newFlags(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.BoolVar(&assumeYes, "yes", false, "answer yes to every confirmation")
+	fs.BoolVar(&assumeYes, "y", false, "shorthand for --yes")
+	fs.BoolVar(&jsonOutput, "json", false, "print a JSON result object to stdout")
+	return fs
+}
+
+func parse(fs *flag.FlagSet, args []string) bool {
+	fs.SetOutput(os.Stderr)
+	if fs.Parse(flagsFirst(fs, args)) != nil {
+		return false
+	}
+
+	// Keep stdout clean for the JSON result
+	if jsonOutput {
+		ui.SetOutput(os.Stderr)
+	}
+	if assumeYes {
+		ui.SetPrompter(ui.AssumeYesPrompter{Next: ui.CurrentPrompter()})
+	}
+	return true
+}
+
+/*
+flagsFirst moves flags in front of the positional arguments
+(the flag package stops at the first positional one):
+
+	switch feature --stash → --stash -- feature
+
+Values of non-boolean flags ("-m msg") stay with their flag,
+everything after "--" stays positional
+*/
+func flagsFirst(fs *flag.FlagSet, args []string) []string {
+	var flags, positional []string
+
+	for i := 0; i < len(args); i++ {
+		a := args[i]
+		switch {
+		case a == "--":
+			positional = append(positional, args[i+1:]...)
+			i = len(args)
+			continue
+		case len(a) < 2 || a[0] != '-':
+			positional = append(positional, a)
+			continue
+		}
+
+		flags = append(flags, a)
+		name := strings.TrimLeft(a, "-")
+		if strings.Contains(name, "=") {
+			continue
+		}
+		f := fs.Lookup(name)
+		if f == nil {
+			continue // fs.Parse reports it
+		}
+		if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+			continue
+		}
+		if i+1 < len(args) {
+			i++
+			flags = append(flags, args[i])
+		}
+	}
+
+	return append(append(flags, "--"), positional...)
+}
+
+// runOp offers git init when needed (honours --yes), then runs op
+func runOp(name string, op func() (gitops.Result, error)) int {
+	if !system.EnsureGitRepo() {
+		return finish(gitops.Result{Operation: name}, gitops.ErrNotRepo)
+	}
+	return finish(op())
+}
+
+/*
+finish reports the outcome of an operation and returns its exit code
+In JSON mode the result object is the ONLY thing written to stdout
+*/
+func finish(res gitops.Result, err error) int {
+	if jsonOutput {
+		emitJSON(res, err)
+	} else {
+		menu.Render(res, err)
+	}
+	return exitCode(err)
+}
+
+func exitCode(err error) int {
+	if err != nil {
+		return ExitFailed
+	}
+	return ExitOK
+}
+
+func usage(w io.Writer, code int) int {
+	fmt.Fprintln(w, "Usage: git-genius [command] [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run without a command to open the interactive menu.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+
+	for _, c := range commands {
+		line := c.name
+		if c.args != "" {
+			line += " " + c.args
+		}
+		fmt.Fprintf(w, "  %-40s %s\n", line, c.summary)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Flags:")
+	fmt.Fprintf(w, "  %-40s %s\n", "-y, --yes", "Answer yes to every confirmation")
+	fmt.Fprintf(w, "  %-40s %s\n", "--json", "Print a JSON result object to stdout")
+	fmt.Fprintf(w, "  %-40s %s\n", "--allow-secrets (push)", "Skip the secret scan")
+	fmt.Fprintf(w, "  %-40s %s\n", "--allow-large (push)", "Commit files over the size limit")
+	fmt.Fprintf(w, "  %-40s %s\n", "--author/--grep/--since/--until (log)", "Filter history")
+	fmt.Fprintf(w, "  %-40s %s\n", "--all (log)", "Include every branch")
+	fmt.Fprintf(w, "  %-40s %s\n", "--all / --to REV (diff)", "Everything uncommitted / compare to REV")
+	fmt.Fprintf(w, "  %-40s %s\n", "--side-by-side (diff)", "Two columns on wide terminals")
+	fmt.Fprintf(w, "  %-40s %s\n", "-u / -a (stash save)", "Include untracked / untracked + ignored files")
+	fmt.Fprintf(w, "  %-40s %s\n", "--keep-index (stash save)", "Leave staged changes in the work tree")
+	fmt.Fprintf(w, "  %-40s %s\n", "--title T (changelog)", "Section heading (default: tag or Unreleased)")
+	fmt.Fprintf(w, "  %-40s %s\n", "--commit (changelog --write)", "Commit "+gitops.ChangelogFile)
+	return code
+}
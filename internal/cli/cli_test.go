@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"flag"
+	"reflect"
+	"testing"
+)
+
+func TestFlagsFirst(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"flag after positional", []string{"feature", "--stash"}, []string{"--stash", "--", "feature"}},
+		{"value stays with its flag", []string{"a.go", "-m", "fix: typo", "b.go"}, []string{"-m", "fix: typo", "--", "a.go", "b.go"}},
+		{"inline value", []string{"a.go", "-m=msg"}, []string{"-m=msg", "--", "a.go"}},
+		{"double dash keeps the rest positional", []string{"--stash", "--", "--carry"}, []string{"--stash", "--", "--carry"}},
+		{"single dash is positional", []string{"-"}, []string{"--", "-"}},
+		{"unknown flag is left for Parse", []string{"x", "--nope"}, []string{"--nope", "--", "x"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.Bool("stash", false, "")
+			fs.Bool("carry", false, "")
+			fs.String("m", "", "")
+
+			if got := flagsFirst(fs, tt.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("flagsFirst(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSwitchFlagAfterBranch(t *testing.T) {
+	fs := newFlags("switch")
+	stash := fs.Bool("stash", false, "")
+	if !parse(fs, []string{"feature", "--stash"}) {
+		t.Fatal("parse failed")
+	}
+	if !*stash || fs.NArg() != 1 || fs.Arg(0) != "feature" {
+		t.Errorf("stash = %v, args = %q; want true, [feature]", *stash, fs.Args())
+	}
+}
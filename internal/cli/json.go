@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+
+	"git-genius/internal/gitops"
+)
+
+// jsonOutput is set by the --json flag
+var jsonOutput = false
+
+/*
+jsonResult is the stable envelope consumed by wrappers / IDE plugins
+
+	{
+	  "operation":  "push",
+	  "success":    false,
+	  "summary":    "",
+	  "refs":       ["main", "origin/main"],
+	  "warnings":   [],
+	  "error_kind": "no_remote",
+	  "error":      "no remote configured",
+	  "output":     "…captured git output…",
+	  "data":       {...}
+	}
+*/
+type jsonResult struct {
+	Operation string   `json:"operation"`
+	Success   bool     `json:"success"`
+	Summary   string   `json:"summary,omitempty"`
+	Refs      []string `json:"refs"`
+	Warnings  []string `json:"warnings"`
+	ErrorKind string   `json:"error_kind,omitempty"`
+	Error     string   `json:"error,omitempty"`
+	Output    string   `json:"output,omitempty"`
+	Data      any      `json:"data,omitempty"`
+}
+
+func emitJSON(res gitops.Result, err error) {
+	out := jsonResult{
+		Operation: res.Operation,
+		Success:   err == nil,
+		Summary:   res.Summary,
+		Refs:      res.Refs,
+		Warnings:  res.Warnings,
+		ErrorKind: gitops.ErrorKind(err),
+		Output:    res.Output,
+		Data:      res.Data,
+	}
+
+	if out.Refs == nil {
+		out.Refs = []string{}
+	}
+	if out.Warnings == nil {
+		out.Warnings = []string{}
+	}
+	if err != nil {
+		out.Error = err.Error()
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(out)
+}
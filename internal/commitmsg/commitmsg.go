@@ -0,0 +1,188 @@
+package commitmsg
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"git-genius/internal/config"
+)
+
+/* ============================================================
+   CONVENTIONAL COMMIT MESSAGES
+   ============================================================
+
+   type(scope)!: subject
+
+   body
+
+   BREAKING CHANGE: description
+   Refs: #12
+*/
+
+// Message is a structured commit message
+type Message struct {
+	Type     string
+	Scope    string
+	Breaking bool
+	Subject  string
+	Body     string
+	Footers  []string // "Refs: #12", "BREAKING CHANGE: …"
+}
+
+var headerRe = regexp.MustCompile(`^([a-zA-Z]+)(\(([^()]+)\))?(!)?: (.+)$`)
+
+// TypeDescriptions help beginners pick a type
+var TypeDescriptions = map[string]string{
+	"feat":     "a new feature",
+	"fix":      "a bug fix",
+	"docs":     "documentation only",
+	"style":    "formatting, no code change",
+	"refactor": "code change that is neither fix nor feature",
+	"perf":     "performance improvement",
+	"test":     "adding or fixing tests",
+	"build":    "build system or dependencies",
+	"ci":       "CI configuration",
+	"chore":    "maintenance, tooling",
+	"revert":   "revert a previous commit",
+}
+
+// Format renders the full commit message
+func (m Message) Format() string {
+	var b strings.Builder
+
+	b.WriteString(m.Header())
+
+	if body := strings.TrimSpace(m.Body); body != "" {
+		b.WriteString("\n\n" + body)
+	}
+	if len(m.Footers) > 0 {
+		b.WriteString("\n\n" + strings.Join(m.Footers, "\n"))
+	}
+	return b.String()
+}
+
+// Header renders the first line: type(scope)!: subject
+func (m Message) Header() string {
+	h := m.Type
+	if m.Scope != "" {
+		h += "(" + m.Scope + ")"
+	}
+	if m.Breaking {
+		h += "!"
+	}
+	return h + ": " + m.Subject
+}
+
+/*
+Parse splits a raw message into its conventional parts
+ok = false when the header is not conventional
+*/
+func Parse(raw string) (Message, bool) {
+	raw = strings.TrimSpace(raw)
+	header, rest, _ := strings.Cut(raw, "\n")
+
+	match := headerRe.FindStringSubmatch(strings.TrimSpace(header))
+	if match == nil {
+		return Message{Subject: header, Body: strings.TrimSpace(rest)}, false
+	}
+
+	m := Message{
+		Type:     strings.ToLower(match[1]),
+		Scope:    match[3],
+		Breaking: match[4] == "!",
+		Subject:  match[5],
+	}
+
+	// Trailing block of "Token: value" lines = footers
+	paras := strings.Split(strings.TrimSpace(rest), "\n\n")
+	if last := paras[len(paras)-1]; isFooterBlock(last) {
+		m.Footers = strings.Split(last, "\n")
+		paras = paras[:len(paras)-1]
+	}
+	m.Body = strings.TrimSpace(strings.Join(paras, "\n\n"))
+
+	for _, f := range m.Footers {
+		if strings.HasPrefix(f, "BREAKING CHANGE:") || strings.HasPrefix(f, "BREAKING-CHANGE:") {
+			m.Breaking = true
+		}
+	}
+	return m, true
+}
+
+var footerRe = regexp.MustCompile(`^([A-Za-z-]+|BREAKING CHANGE): |^[A-Za-z-]+ #`)
+
+func isFooterBlock(block string) bool {
+	if strings.TrimSpace(block) == "" {
+		return false
+	}
+	for _, line := range strings.Split(block, "\n") {
+		if !footerRe.MatchString(line) {
+			return false
+		}
+	}
+	return true
+}
+
+/* ============================================================
+   VALIDATION
+   ============================================================ */
+
+/*
+Validate checks raw against the configured rules
+Returns human readable problems (empty = message is fine)
+*/
+func Validate(raw string, rules config.CommitRules) []string {
+	if rules.Enforcement == config.EnforceOff {
+		return nil
+	}
+
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return []string{"Commit message is empty"}
+	}
+
+	var problems []string
+	header, _, _ := strings.Cut(raw, "\n")
+
+	if limit := rules.MaxSubjectLength; limit > 0 && len([]rune(header)) > limit {
+		problems = append(problems,
+			fmt.Sprintf("First line is %d characters (max %d)", len([]rune(header)), limit))
+	}
+
+	if rules.Conventional {
+		m, ok := Parse(raw)
+		switch {
+		case !ok:
+			problems = append(problems, "First line must look like: type(scope): subject")
+		case !allowed(m.Type, rules.AllowedTypes):
+			problems = append(problems,
+				"Type '"+m.Type+"' not allowed (use: "+strings.Join(rules.AllowedTypes, ", ")+")")
+		case strings.TrimSpace(m.Subject) == "":
+			problems = append(problems, "Subject cannot be empty")
+		}
+	}
+
+	if rules.TicketPattern != "" {
+		re, err := regexp.Compile(rules.TicketPattern)
+		if err != nil {
+			problems = append(problems, "Invalid ticket pattern in config: "+rules.TicketPattern)
+		} else if !re.MatchString(raw) {
+			problems = append(problems, "Ticket reference required (pattern: "+rules.TicketPattern+")")
+		}
+	}
+
+	return problems
+}
+
+func allowed(t string, types []string) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, a := range types {
+		if strings.EqualFold(a, t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package commitmsg
+
+import (
+	"reflect"
+	"testing"
+
+	"git-genius/internal/config"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want Message
+		ok   bool
+	}{
+		{
+			raw:  "feat: add login",
+			want: Message{Type: "feat", Subject: "add login"},
+			ok:   true,
+		},
+		{
+			raw:  "Fix(api)!: drop v1\n\nThe old endpoints are gone.\n\nBREAKING CHANGE: use /v2\nRefs: #12",
+			want: Message{Type: "fix", Scope: "api", Breaking: true, Subject: "drop v1", Body: "The old endpoints are gone.", Footers: []string{"BREAKING CHANGE: use /v2", "Refs: #12"}},
+			ok:   true,
+		},
+		{
+			raw:  "refactor: split parser\n\nBREAKING-CHANGE: Parse returns two values",
+			want: Message{Type: "refactor", Breaking: true, Subject: "split parser", Footers: []string{"BREAKING-CHANGE: Parse returns two values"}},
+			ok:   true,
+		},
+		{
+			raw:  "docs: readme\n\nCloses #3",
+			want: Message{Type: "docs", Subject: "readme", Footers: []string{"Closes #3"}},
+			ok:   true,
+		},
+		{
+			raw:  "chore: tidy\n\nNote: this is body text\nbecause this line is not a footer",
+			want: Message{Type: "chore", Subject: "tidy", Body: "Note: this is body text\nbecause this line is not a footer"},
+			ok:   true,
+		},
+		{
+			raw:  "Update readme\n\nmore words",
+			want: Message{Subject: "Update readme", Body: "more words"},
+			ok:   false,
+		},
+		{
+			raw:  "feat:missing space",
+			want: Message{Subject: "feat:missing space"},
+			ok:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		got, ok := Parse(tt.raw)
+		if ok != tt.ok || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Parse(%q)\n got %+v, %v\nwant %+v, %v", tt.raw, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	m := Message{Type: "feat", Scope: "ui", Breaking: true, Subject: "new menu", Body: "Reworked.", Footers: []string{"Refs: #4"}}
+
+	got, ok := Parse(m.Format())
+	if !ok || !reflect.DeepEqual(got, m) {
+		t.Errorf("Parse(Format()) = %+v, %v; want %+v", got, ok, m)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	conventional := config.CommitRules{
+		Enforcement:      config.EnforceBlock,
+		Conventional:     true,
+		AllowedTypes:     []string{"feat", "fix"},
+		MaxSubjectLength: 20,
+	}
+
+	tests := []struct {
+		name  string
+		raw   string
+		rules config.CommitRules
+		want  []string
+	}{
+		{"off", "", config.CommitRules{Enforcement: config.EnforceOff}, nil},
+		{"empty", "  ", conventional, []string{"Commit message is empty"}},
+		{"valid", "fix: typo", conventional, nil},
+		{"allowed type ignores case", "FIX: typo", conventional, nil},
+		{"too long", "fix: a much longer subject", conventional, []string{"First line is 26 characters (max 20)"}},
+		{"length rule off", "fix: a much longer subject", config.CommitRules{Enforcement: config.EnforceBlock, MaxSubjectLength: -1}, nil},
+		{"not conventional", "typo", conventional, []string{"First line must look like: type(scope): subject"}},
+		{"type not allowed", "docs: typo", conventional, []string{"Type 'docs' not allowed (use: feat, fix)"}},
+		{"ticket missing", "fix: typo", config.CommitRules{Enforcement: config.EnforceWarn, TicketPattern: `[A-Z]+-\d+`},
+			[]string{`Ticket reference required (pattern: [A-Z]+-\d+)`}},
+		{"ticket in body", "fix: typo\n\nABC-12", config.CommitRules{Enforcement: config.EnforceWarn, TicketPattern: `[A-Z]+-\d+`}, nil},
+		{"bad ticket pattern", "fix: typo", config.CommitRules{Enforcement: config.EnforceWarn, TicketPattern: `(`},
+			[]string{"Invalid ticket pattern in config: ("}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Validate(tt.raw, tt.rules); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
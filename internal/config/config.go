@@ -32,6 +32,41 @@ type Config struct {
 	/* ---------------- Project directory ---------------- */
 	// Empty = current working directory
 	WorkDir string `json:"work_dir"`
+
+	/* ---------------- Commit messages ---------------- */
+	CommitRules CommitRules `json:"commit_rules"`
+
+	/* ---------------- Large files ---------------- */
+	LargeFileMB int `json:"large_file_mb"` // files above this need confirmation
+}
+
+// DefaultLargeFileMB matches GitHub's large file warning
+const DefaultLargeFileMB = 50
+
+// Commit message enforcement levels
+const (
+	EnforceOff   = "off"   // no validation
+	EnforceWarn  = "warn"  // report problems, commit anyway
+	EnforceBlock = "block" // refuse non-conforming messages
+)
+
+// CommitRules controls commit message validation
+type CommitRules struct {
+	Enforcement      string   `json:"enforcement"`        // off / warn / block
+	Conventional     bool     `json:"conventional"`       // require type(scope): subject
+	AllowedTypes     []string `json:"allowed_types"`      // conventional types
+	MaxSubjectLength int      `json:"max_subject_length"` // 0 = default (72), negative = no limit
+	TicketPattern    string   `json:"ticket_pattern"`     // regexp, empty = not required
+	Wizard           bool     `json:"wizard"`             // always use guided composer
+}
+
+// DefaultMaxSubjectLength applies when max_subject_length is 0 (-1 turns the rule off)
+const DefaultMaxSubjectLength = 72
+
+// DefaultCommitTypes are the Conventional Commits types offered by default
+var DefaultCommitTypes = []string{
+	"feat", "fix", "docs", "style", "refactor",
+	"perf", "test", "build", "ci", "chore", "revert",
 }
 
 /* ============================================================
@@ -88,6 +123,16 @@ func defaultConfig() Config {
 		RepoCreated:   false,
 		FirstPushDone: false,
 		WorkDir:       "",
+		CommitRules:   defaultCommitRules(),
+		LargeFileMB:   DefaultLargeFileMB,
+	}
+}
+
+func defaultCommitRules() CommitRules {
+	return CommitRules{
+		Enforcement:      EnforceWarn,
+		AllowedTypes:     append([]string(nil), DefaultCommitTypes...),
+		MaxSubjectLength: DefaultMaxSubjectLength,
 	}
 }
 
@@ -102,6 +147,21 @@ func applyDefaults(c *Config) {
 	if c.Remote == "" {
 		c.Remote = "origin"
 	}
+
+	// Commit rules (older configs have none)
+	if c.CommitRules.Enforcement == "" {
+		c.CommitRules.Enforcement = EnforceWarn
+	}
+	if len(c.CommitRules.AllowedTypes) == 0 {
+		c.CommitRules.AllowedTypes = append([]string(nil), DefaultCommitTypes...)
+	}
+	if c.CommitRules.MaxSubjectLength == 0 {
+		c.CommitRules.MaxSubjectLength = DefaultMaxSubjectLength
+	}
+
+	if c.LargeFileMB <= 0 {
+		c.LargeFileMB = DefaultLargeFileMB
+	}
 }
 
 // normalizePaths ensures WorkDir is absolute
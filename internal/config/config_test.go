@@ -0,0 +1,25 @@
+package config
+
+import "testing"
+
+func TestApplyDefaultsMaxSubjectLength(t *testing.T) {
+	tests := []struct {
+		name string
+		in   int
+		want int
+	}{
+		{"unset uses the default", 0, DefaultMaxSubjectLength},
+		{"explicit limit", 50, 50},
+		{"negative turns the rule off", -1, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := Config{CommitRules: CommitRules{MaxSubjectLength: tt.in}}
+			applyDefaults(&c)
+			if got := c.CommitRules.MaxSubjectLength; got != tt.want {
+				t.Errorf("MaxSubjectLength = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,336 @@
+package diffview
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"git-genius/internal/gitops"
+	"git-genius/internal/ui"
+)
+
+/* ============================================================
+   Diff rendering (unified or side by side)
+   ============================================================ */
+
+// Options controls how a file diff is drawn
+type Options struct {
+	SideBySide bool
+	Words      bool // highlight changed words inside changed lines
+	Width      int  // terminal columns (0 = ask the terminal)
+}
+
+// MinSideBySideWidth is the narrowest terminal that gets two columns
+const MinSideBySideWidth = 100
+
+const (
+	reverse   = "\033[7m"
+	noReverse = "\033[27m"
+	dim       = "\033[2m"
+	tabWidth  = 4
+	gutter    = 5 // "1234 "
+)
+
+// row is one diff line with its line numbers
+type row struct {
+	kind  byte // ' ', '-', '+', '\\'
+	oldNo int
+	newNo int
+	spans []span
+}
+
+// Header prints the file name, status and +/- counts
+func Header(w io.Writer, fd gitops.FileDiff) {
+	name := fd.Path
+	if fd.OldPath != "" {
+		name = fd.OldPath + " → " + fd.Path
+	}
+	added, deleted := fd.Stats()
+	fmt.Fprintf(w, "%s%s%s  %s  %s+%d%s %s-%d%s\n", ui.Bold, name, ui.Reset, fd.Status,
+		ui.Green, added, ui.Reset, ui.Red, deleted, ui.Reset)
+}
+
+/*
+Render draws one file diff
+Side by side falls back to unified on terminals narrower than MinSideBySideWidth
+*/
+func Render(w io.Writer, fd gitops.FileDiff, opts Options) {
+	if opts.Width <= 0 {
+		opts.Width = ui.TerminalWidth()
+	}
+
+	Header(w, fd)
+
+	if fd.Binary {
+		fmt.Fprintln(w, ui.Yellow+"Binary file changed"+ui.Reset)
+		return
+	}
+	if len(fd.Hunks) == 0 {
+		fmt.Fprintln(w, dim+"No content changes (mode or rename only)"+ui.Reset)
+		return
+	}
+
+	sideBySide := opts.SideBySide && opts.Width >= MinSideBySideWidth
+
+	for _, h := range fd.Hunks {
+		fmt.Fprintln(w, ui.Cyan+h.Header()+ui.Reset)
+
+		rows := hunkRows(h, opts.Words)
+		if sideBySide {
+			renderColumns(w, rows, opts.Width)
+		} else {
+			renderUnified(w, rows)
+		}
+	}
+}
+
+// hunkRows numbers the lines and pairs -/+ runs for word highlighting
+func hunkRows(h gitops.Hunk, words bool) []row {
+	rows := make([]row, 0, len(h.Lines))
+	oldNo, newNo := h.OldStart, h.NewStart
+
+	for _, l := range h.Lines {
+		if l == "" {
+			l = " "
+		}
+		r := row{kind: l[0], spans: []span{{text: expandTabs(l[1:])}}}
+
+		switch r.kind {
+		case '-':
+			r.oldNo = oldNo
+			oldNo++
+		case '+':
+			r.newNo = newNo
+			newNo++
+		case '\\':
+			r.spans = []span{{text: l}}
+		default:
+			r.kind = ' '
+			r.oldNo, r.newNo = oldNo, newNo
+			oldNo++
+			newNo++
+		}
+		rows = append(rows, r)
+	}
+
+	forEachBlock(rows, func(removed, added []int) {
+		for i := 0; i < len(removed) && i < len(added); i++ {
+			a, b := &rows[removed[i]], &rows[added[i]]
+			if words {
+				a.spans, b.spans = wordDiff(a.spans[0].text, b.spans[0].text)
+			}
+		}
+	})
+	return rows
+}
+
+// forEachBlock calls fn for every run of removed lines followed by added lines
+func forEachBlock(rows []row, fn func(removed, added []int)) {
+	for i := 0; i < len(rows); {
+		if rows[i].kind != '-' && rows[i].kind != '+' {
+			i++
+			continue
+		}
+
+		var removed, added []int
+		for ; i < len(rows) && (rows[i].kind == '-' || rows[i].kind == '\\') && len(added) == 0; i++ {
+			if rows[i].kind == '-' {
+				removed = append(removed, i)
+			}
+		}
+		for ; i < len(rows) && (rows[i].kind == '+' || rows[i].kind == '\\'); i++ {
+			if rows[i].kind == '+' {
+				added = append(added, i)
+			}
+		}
+		fn(removed, added)
+	}
+}
+
+func renderUnified(w io.Writer, rows []row) {
+	for _, r := range rows {
+		switch r.kind {
+		case '\\':
+			fmt.Fprintln(w, dim+r.spans[0].text+ui.Reset)
+		case '-':
+			fmt.Fprintf(w, "%s%s\n", lineNumbers(r), paint(append([]span{{text: "-"}}, r.spans...), ui.Red))
+		case '+':
+			fmt.Fprintf(w, "%s%s\n", lineNumbers(r), paint(append([]span{{text: "+"}}, r.spans...), ui.Green))
+		default:
+			fmt.Fprintf(w, "%s %s\n", lineNumbers(r), r.spans[0].text)
+		}
+	}
+}
+
+func lineNumbers(r row) string {
+	return dim + number(r.oldNo) + number(r.newNo) + ui.Reset
+}
+
+func number(n int) string {
+	if n == 0 {
+		return strings.Repeat(" ", gutter)
+	}
+	return fmt.Sprintf("%4d ", n)
+}
+
+/*
+renderColumns prints old (left) and new (right) next to each other
+Long lines wrap inside their column
+*/
+func renderColumns(w io.Writer, rows []row, width int) {
+	col := (width - 3) / 2 // " │ " between the columns
+	text := col - gutter
+
+	var left, right []*row
+	flush := func() {
+		for i := 0; i < max(len(left), len(right)); i++ {
+			var l, r *row
+			if i < len(left) {
+				l = left[i]
+			}
+			if i < len(right) {
+				r = right[i]
+			}
+			printPair(w, l, r, text)
+		}
+		left, right = nil, nil
+	}
+
+	for i := range rows {
+		r := &rows[i]
+		switch r.kind {
+		case '\\':
+		case '-':
+			if len(right) > 0 {
+				flush()
+			}
+			left = append(left, r)
+		case '+':
+			right = append(right, r)
+		default:
+			flush()
+			left, right = []*row{r}, []*row{r}
+			flush()
+		}
+	}
+	flush()
+}
+
+// printPair prints one left / right row, wrapping both columns together
+func printPair(w io.Writer, l, r *row, text int) {
+	lLines, rLines := cell(l, '-', text), cell(r, '+', text)
+
+	for i := 0; i < max(len(lLines), len(rLines)); i++ {
+		a, b := strings.Repeat(" ", gutter+text), ""
+		if i < len(lLines) {
+			a = lLines[i]
+		}
+		if i < len(rLines) {
+			b = rLines[i]
+		}
+		fmt.Fprintf(w, "%s %s│%s %s\n", a, dim, ui.Reset, b)
+	}
+}
+
+// cell renders a row as fixed-width column lines (first line numbered)
+func cell(r *row, side byte, text int) []string {
+	if r == nil {
+		return []string{strings.Repeat(" ", gutter+text)}
+	}
+
+	no, color := r.oldNo, ui.Red
+	if side == '+' {
+		no, color = r.newNo, ui.Green
+	}
+	if r.kind == ' ' {
+		color = ""
+	}
+
+	var lines []string
+	for i, part := range wrapSpans(r.spans, text) {
+		num := strings.Repeat(" ", gutter)
+		if i == 0 {
+			num = number(no)
+		}
+		lines = append(lines, dim+num+ui.Reset+paint(part, color)+strings.Repeat(" ", text-spanWidth(part)))
+	}
+	return lines
+}
+
+// paint colours spans; changed spans are shown in reverse video
+func paint(spans []span, color string) string {
+	var b strings.Builder
+	for _, s := range spans {
+		b.WriteString(color)
+		if s.changed {
+			b.WriteString(reverse + s.text + noReverse)
+		} else {
+			b.WriteString(s.text)
+		}
+	}
+	if color != "" {
+		b.WriteString(ui.Reset)
+	}
+	return b.String()
+}
+
+// wrapSpans cuts spans into lines of at most width runes
+func wrapSpans(spans []span, width int) [][]span {
+	lines := [][]span{nil}
+	used := 0
+
+	for _, s := range spans {
+		rest := s.text
+		for rest != "" {
+			if used == width {
+				lines = append(lines, nil)
+				used = 0
+			}
+			take := takeRunes(rest, width-used)
+			lines[len(lines)-1] = append(lines[len(lines)-1], span{text: take, changed: s.changed})
+			used += utf8.RuneCountInString(take)
+			rest = rest[len(take):]
+		}
+	}
+	return lines
+}
+
+func takeRunes(s string, n int) string {
+	i := 0
+	for pos := range s {
+		if i == n {
+			return s[:pos]
+		}
+		i++
+	}
+	return s
+}
+
+func spanWidth(spans []span) int {
+	n := 0
+	for _, s := range spans {
+		n += utf8.RuneCountInString(s.text)
+	}
+	return n
+}
+
+func expandTabs(s string) string {
+	if !strings.Contains(s, "\t") {
+		return s
+	}
+
+	var b strings.Builder
+	col := 0
+	for _, r := range s {
+		if r == '\t' {
+			pad := tabWidth - col%tabWidth
+			b.WriteString(strings.Repeat(" ", pad))
+			col += pad
+			continue
+		}
+		b.WriteRune(r)
+		col++
+	}
+	return b.String()
+}
@@ -0,0 +1,125 @@
+package diffview
+
+import (
+	"bytes"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"git-genius/internal/gitops"
+)
+
+var ansi = regexp.MustCompile("\033\\[[0-9;]*m")
+
+// sampleDiff changes the second line of main.go
+var sampleDiff = gitops.FileDiff{
+	Path:   "main.go",
+	Status: "modified",
+	Hunks: []gitops.Hunk{{
+		OldStart: 1, OldLines: 3, NewStart: 1, NewLines: 3,
+		Lines: []string{" a := 1", "-b := 2", "+b := 3", " c"},
+	}},
+}
+
+// render returns the raw output and its lines without colours
+func render(fd gitops.FileDiff, opts Options) (string, []string) {
+	var buf bytes.Buffer
+	Render(&buf, fd, opts)
+	plain := ansi.ReplaceAllString(buf.String(), "")
+	return buf.String(), strings.Split(strings.TrimRight(plain, "\n"), "\n")
+}
+
+func TestRenderUnified(t *testing.T) {
+	raw, got := render(sampleDiff, Options{Width: 80, Words: true})
+	want := []string{
+		"main.go  modified  +1 -1",
+		"@@ -1,3 +1,3 @@",
+		"   1    1  a := 1",
+		"   2      -b := 2",
+		"        2 +b := 3",
+		"   3    3  c",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Render()\n got %q\nwant %q", got, want)
+	}
+	for _, word := range []string{"2", "3"} {
+		if !strings.Contains(raw, reverse+word+noReverse) {
+			t.Errorf("changed word %q not highlighted", word)
+		}
+	}
+}
+
+func TestRenderWithoutWords(t *testing.T) {
+	raw, _ := render(sampleDiff, Options{Width: 80})
+	if strings.Contains(raw, reverse) {
+		t.Error("words highlighted with Words off")
+	}
+}
+
+func TestRenderSideBySide(t *testing.T) {
+	tests := []struct {
+		name    string
+		width   int
+		columns bool
+	}{
+		{"wide terminal", MinSideBySideWidth, true},
+		{"narrow terminal falls back to unified", MinSideBySideWidth - 1, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, lines := render(sampleDiff, Options{Width: tt.width, SideBySide: true})
+
+			paired := false
+			for _, l := range lines {
+				if strings.Contains(l, "│") && strings.Contains(l, "b := 2") && strings.Contains(l, "b := 3") {
+					paired = true
+				}
+				if n := len([]rune(l)); n > tt.width {
+					t.Errorf("line is %d columns wide: %q", n, l)
+				}
+			}
+			if paired != tt.columns {
+				t.Errorf("old and new on one line = %v, want %v\n%s", paired, tt.columns, strings.Join(lines, "\n"))
+			}
+		})
+	}
+}
+
+func TestRenderWithoutHunks(t *testing.T) {
+	tests := []struct {
+		name string
+		fd   gitops.FileDiff
+		want string
+	}{
+		{"binary", gitops.FileDiff{Path: "logo.png", Binary: true}, "Binary file changed"},
+		{"rename only", gitops.FileDiff{Path: "new.go", OldPath: "old.go", Status: "renamed"}, "No content changes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, lines := render(tt.fd, Options{Width: 80})
+			if len(lines) != 2 || !strings.HasPrefix(lines[1], tt.want) {
+				t.Errorf("Render() = %q, want the header and %q", lines, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrapSpans(t *testing.T) {
+	got := wrapSpans([]span{{text: "abcdef"}, {text: "gh", changed: true}}, 4)
+	want := [][]span{
+		{{text: "abcd"}},
+		{{text: "ef"}, {text: "gh", changed: true}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("wrapSpans() = %+v, want %+v", got, want)
+	}
+}
+
+func TestExpandTabs(t *testing.T) {
+	if got := expandTabs("a\tbc\td"); got != "a   bc  d" {
+		t.Errorf("expandTabs() = %q", got)
+	}
+}
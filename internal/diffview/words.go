@@ -0,0 +1,136 @@
+package diffview
+
+import (
+	"strings"
+	"unicode"
+)
+
+/* ============================================================
+   Word-level changes between a removed and an added line
+   ============================================================ */
+
+// span is a piece of a line; changed pieces are highlighted
+type span struct {
+	text    string
+	changed bool
+}
+
+// maxWordCells caps the LCS table (long minified lines stay line-level)
+const maxWordCells = 40000
+
+// minSimilarity: below this share of common text the whole line is "changed"
+const minSimilarity = 0.3
+
+/*
+wordDiff splits old / new into spans, marking the tokens that differ
+Tokens are words, runs of spaces or single punctuation characters
+*/
+func wordDiff(oldLine, newLine string) ([]span, []span) {
+	a, b := tokenize(oldLine), tokenize(newLine)
+
+	whole := func() ([]span, []span) {
+		return []span{{text: oldLine, changed: true}}, []span{{text: newLine, changed: true}}
+	}
+	if len(a)*len(b) > maxWordCells {
+		return whole()
+	}
+
+	keepA, keepB := lcs(a, b)
+
+	common := 0
+	for i, t := range a {
+		if keepA[i] {
+			common += len(t)
+		}
+	}
+	if longest := max(len(oldLine), len(newLine)); longest > 0 && float64(common)/float64(longest) < minSimilarity {
+		return whole()
+	}
+
+	return toSpans(a, keepA), toSpans(b, keepB)
+}
+
+// lcs marks the tokens of a and b that belong to a longest common subsequence
+func lcs(a, b []string) ([]bool, []bool) {
+	n, m := len(a), len(b)
+	table := make([][]int, n+1)
+	for i := range table {
+		table[i] = make([]int, m+1)
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				table[i][j] = table[i+1][j+1] + 1
+			} else {
+				table[i][j] = max(table[i+1][j], table[i][j+1])
+			}
+		}
+	}
+
+	keepA, keepB := make([]bool, n), make([]bool, m)
+	for i, j := 0, 0; i < n && j < m; {
+		switch {
+		case a[i] == b[j]:
+			keepA[i], keepB[j] = true, true
+			i++
+			j++
+		case table[i+1][j] >= table[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return keepA, keepB
+}
+
+// toSpans merges neighbouring tokens with the same state
+func toSpans(tokens []string, keep []bool) []span {
+	var spans []span
+	for i, t := range tokens {
+		changed := !keep[i]
+		if n := len(spans); n > 0 && spans[n-1].changed == changed {
+			spans[n-1].text += t
+			continue
+		}
+		spans = append(spans, span{text: t, changed: changed})
+	}
+	return spans
+}
+
+func tokenize(s string) []string {
+	var tokens []string
+	var cur strings.Builder
+	curKind := 0 // 0 none, 1 word, 2 space
+
+	flush := func() {
+		if cur.Len() > 0 {
+			tokens = append(tokens, cur.String())
+			cur.Reset()
+		}
+		curKind = 0
+	}
+
+	for _, r := range s {
+		kind := 3
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			kind = 1
+		case unicode.IsSpace(r):
+			kind = 2
+		}
+
+		if kind == 3 {
+			flush()
+			tokens = append(tokens, string(r))
+			continue
+		}
+		if kind != curKind {
+			flush()
+			curKind = kind
+		}
+		cur.WriteRune(r)
+	}
+	flush()
+	return tokens
+}
@@ -0,0 +1,68 @@
+package diffview
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	got := tokenize("if x_1 >= 10 {")
+	want := []string{"if", " ", "x_1", " ", ">", "=", " ", "10", " ", "{"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("tokenize() = %q, want %q", got, want)
+	}
+}
+
+func TestWordDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		wantOld  []span
+		wantNew  []span
+	}{
+		{
+			name: "one word changed",
+			old:  "timeout := 30 * time.Second",
+			new:  "timeout := 60 * time.Second",
+			wantOld: []span{
+				{text: "timeout := "}, {text: "30", changed: true}, {text: " * time.Second"},
+			},
+			wantNew: []span{
+				{text: "timeout := "}, {text: "60", changed: true}, {text: " * time.Second"},
+			},
+		},
+		{
+			name:    "word added",
+			old:     "return err",
+			new:     "return nil, err",
+			wantOld: []span{{text: "return err"}},
+			wantNew: []span{{text: "return "}, {text: "nil, ", changed: true}, {text: "err"}},
+		},
+		{
+			name:    "identical",
+			old:     "x := 1",
+			new:     "x := 1",
+			wantOld: []span{{text: "x := 1"}},
+			wantNew: []span{{text: "x := 1"}},
+		},
+		{
+			name:    "too different: whole line",
+			old:     "fmt.Println(greeting)",
+			new:     "return errors.New(msg)",
+			wantOld: []span{{text: "fmt.Println(greeting)", changed: true}},
+			wantNew: []span{{text: "return errors.New(msg)", changed: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotOld, gotNew := wordDiff(tt.old, tt.new)
+			if !reflect.DeepEqual(gotOld, tt.wantOld) {
+				t.Errorf("old spans = %+v, want %+v", gotOld, tt.wantOld)
+			}
+			if !reflect.DeepEqual(gotNew, tt.wantNew) {
+				t.Errorf("new spans = %+v, want %+v", gotNew, tt.wantNew)
+			}
+		})
+	}
+}
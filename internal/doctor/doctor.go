@@ -1,6 +1,8 @@
 package doctor
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -11,100 +13,158 @@ import (
 	"git-genius/internal/ui"
 )
 
+/* ============================================================
+   CHECK RESULTS
+   ============================================================ */
+
+const (
+	StatusOK   = "ok"
+	StatusWarn = "warn"
+	StatusFail = "fail"
+)
+
+// Check is the outcome of a single doctor check
+type Check struct {
+	Name    string   `json:"name"`
+	Status  string   `json:"status"`
+	Message string   `json:"message"`
+	Hints   []string `json:"hints,omitempty"`
+}
+
+func pass(name, msg string, info ...string) Check {
+	ui.Success(msg)
+	return record(name, StatusOK, msg, info)
+}
+
+func warn(name, msg string, hints ...string) Check {
+	ui.Warn(msg)
+	return record(name, StatusWarn, msg, hints)
+}
+
+func fail(name, msg string, hints ...string) Check {
+	ui.Error(msg)
+	return record(name, StatusFail, msg, hints)
+}
+
+func record(name, status, msg string, hints []string) Check {
+	for _, h := range hints {
+		ui.Info(h)
+	}
+	return Check{Name: name, Status: status, Message: msg, Hints: hints}
+}
+
 // Run performs full system + git health check (ANDROID SAFE)
-func Run() {
+// Returns every check result; error when at least one check failed
+func Run() ([]Check, error) {
 	ui.Header("Git Genius Doctor 🩺")
 
 	system.CheckInternet()
 
-	checkGitInstalled()
-	checkWorkDir()
-	checkGitRepo()
-	checkGitBranch()
-	checkGitIdentity()
-	checkRemote()
-	checkInternet()
-	checkGitHubToken()
-	checkGitHubRepo()
-	checkErrorLog()
+	checks := []func() Check{
+		checkGitInstalled,
+		checkWorkDir,
+		checkGitRepo,
+		checkGitBranch,
+		checkGitIdentity,
+		checkRemote,
+		checkGitLFS,
+		checkInternet,
+		checkGitHubToken,
+		checkGitHubRepo,
+		checkErrorLog,
+	}
+
+	var results []Check
+	failed := 0
+	for _, check := range checks {
+		c := check()
+		if c.Status == StatusFail {
+			failed++
+		}
+		results = append(results, c)
+	}
+
+	if failed > 0 {
+		ui.Warn(fmt.Sprintf("Doctor found %d problem(s)", failed))
+		return results, errors.New("doctor checks failed")
+	}
 
 	ui.Success("Doctor check completed")
+	return results, nil
 }
 
 /* ============================================================
    CHECKS
    ============================================================ */
 
-func checkGitInstalled() {
+func checkGitInstalled() Check {
 	if system.CommandExists("git") {
-		ui.Success("Git installed")
-		return
+		return pass("git", "Git installed")
 	}
 
-	ui.Error("Git not found in PATH")
-	ui.Info("Please install git manually for your environment")
+	return fail("git", "Git not found in PATH",
+		"Please install git manually for your environment")
 }
 
-func checkWorkDir() {
+func checkWorkDir() Check {
 	cfg := config.Load()
 	dir := cfg.GetWorkDir()
 
 	info, err := os.Stat(dir)
 	if err != nil || !info.IsDir() {
-		ui.Error("Invalid project directory: " + dir)
-		return
+		return fail("workdir", "Invalid project directory: "+dir)
 	}
 
-	ui.Success("Project directory: " + dir)
+	return pass("workdir", "Project directory: "+dir)
 }
 
-func checkGitRepo() {
+func checkGitRepo() Check {
 	cfg := config.Load()
 	dir := cfg.GetWorkDir()
 
 	if system.IsGitRepoAt(dir) {
-		ui.Success("Git repository detected")
 		system.EnsureSafeDirectory(dir)
-		return
+		return pass("repository", "Git repository detected")
 	}
 
 	ui.Warn("No git repository found")
 
-	if ui.Confirm("Initialize git repository here?") {
-		if err := system.RunGitAt(dir, "init"); err != nil {
-			ui.Error("Failed to initialize git repository")
-			return
-		}
-		system.EnsureSafeDirectory(dir)
-		ui.Success("Git repository initialized")
+	if !ui.Confirm("Initialize git repository here?") {
+		return record("repository", StatusWarn, "No git repository found", nil)
+	}
+
+	if err := system.RunGitAt(dir, "init"); err != nil {
+		return fail("repository", "Failed to initialize git repository")
 	}
+	system.EnsureSafeDirectory(dir)
+	return pass("repository", "Git repository initialized")
 }
 
-func checkGitBranch() {
+func checkGitBranch() Check {
 	cfg := config.Load()
 	dir := cfg.GetWorkDir()
 
 	current := system.CurrentGitBranchAt(dir)
 
 	if current == "" {
-		ui.Warn("No commits yet (branch not created)")
-		return
+		return warn("branch", "No commits yet (branch not created)")
 	}
 
-	ui.Success("Current git branch: " + current)
-
 	if current != cfg.Branch {
-		ui.Warn("Branch mismatch detected")
-		ui.Info("Config branch : " + cfg.Branch)
-		ui.Info("Git branch    : " + current)
-		ui.Info("Run Setup to safely sync branch")
+		ui.Success("Current git branch: " + current)
+		return warn("branch", "Branch mismatch detected",
+			"Config branch : "+cfg.Branch,
+			"Git branch    : "+current,
+			"Run Setup to safely sync branch")
 	}
+
+	return pass("branch", "Current git branch: "+current)
 }
 
 /*
 Git identity check (ANDROID SAFE, LOCAL REPO ONLY)
 */
-func checkGitIdentity() {
+func checkGitIdentity() Check {
 	cfg := config.Load()
 	dir := cfg.GetWorkDir()
 
@@ -112,17 +172,16 @@ func checkGitIdentity() {
 	email := gitConfig(dir, "user.email")
 
 	if name != "" && email != "" {
-		ui.Success("Git identity configured")
-		ui.Info("Name : " + name)
-		ui.Info("Email: " + email)
-		return
+		return pass("identity", "Git identity configured",
+			"Name : "+name,
+			"Email: "+email)
 	}
 
-	ui.Warn("Git identity not configured")
-	ui.Info("Commits may appear as root@localhost")
+	c := warn("identity", "Git identity not configured",
+		"Commits may appear as root@localhost")
 
 	if !ui.Confirm("Configure git identity for THIS repo now?") {
-		return
+		return c
 	}
 
 	if name == "" {
@@ -139,90 +198,104 @@ func checkGitIdentity() {
 		}
 	}
 
-	ui.Success("Git identity configured (local repository)")
+	return pass("identity", "Git identity configured (local repository)")
 }
 
-func checkRemote() {
+func checkRemote() Check {
 	cfg := config.Load()
 	dir := cfg.GetWorkDir()
 
 	if cfg.Remote == "" {
-		ui.Warn("No git remote configured")
-		return
+		return warn("remote", "No git remote configured")
 	}
 
 	if err := system.RunGitAt(dir, "remote", "get-url", cfg.Remote); err != nil {
-		ui.Warn("Remote not found: " + cfg.Remote)
-		ui.Info("Run Tools → Create / Link GitHub Repository")
-		return
+		return warn("remote", "Remote not found: "+cfg.Remote,
+			"Run Tools → Create / Link GitHub Repository")
+	}
+
+	return pass("remote", "Git remote configured: "+cfg.Remote)
+}
+
+// checkGitLFS fails only when the repo uses LFS but git-lfs is missing
+func checkGitLFS() Check {
+	dir := config.Load().GetWorkDir()
+	attrs, _ := os.ReadFile(filepath.Join(dir, ".gitattributes"))
+	usesLFS := strings.Contains(string(attrs), "filter=lfs")
+
+	if resp, err := system.Git(system.Request{Args: []string{"lfs", "version"}, Dir: dir}); err == nil {
+		return pass("git-lfs", "Git LFS available: "+strings.Fields(resp.Stdout + " ")[0])
+	}
+
+	if usesLFS {
+		return fail("git-lfs", "Repository uses Git LFS but git-lfs is not installed",
+			"Install git-lfs (pkg install git-lfs / apt install git-lfs)",
+			"Then run: git lfs install && git lfs pull")
 	}
 
-	ui.Success("Git remote configured: " + cfg.Remote)
+	return warn("git-lfs", "Git LFS not installed (needed only for large files)",
+		"Install git-lfs (pkg install git-lfs / apt install git-lfs)")
 }
 
-func checkInternet() {
+func checkInternet() Check {
 	if system.Online {
-		ui.Success("Internet connection available")
-	} else {
-		ui.Warn("Offline mode detected")
-		ui.Info("GitHub validation & push may fail")
+		return pass("internet", "Internet connection available")
 	}
+
+	return warn("internet", "Offline mode detected",
+		"GitHub validation & push may fail")
 }
 
-func checkGitHubToken() {
+func checkGitHubToken() Check {
 	token := github.GetToken()
 	if token == "" {
-		ui.Warn("GitHub token not configured")
-		ui.Info("Run Setup to configure token")
-		return
+		return warn("github-token", "GitHub token not configured",
+			"Run Setup to configure token")
 	}
 
 	user, err := github.Validate()
 	if err != nil {
-		ui.Error("GitHub token invalid or expired")
-		ui.Info("Run Setup to reconfigure token")
-		return
+		return fail("github-token", "GitHub token invalid or expired",
+			"Run Setup to reconfigure token")
 	}
 
 	if user == "offline-mode" {
-		ui.Warn("GitHub token validation skipped (offline)")
-		return
+		return warn("github-token", "GitHub token validation skipped (offline)")
 	}
 
-	ui.Success("GitHub authenticated as: " + user)
+	return pass("github-token", "GitHub authenticated as: "+user)
 }
 
-func checkGitHubRepo() {
+func checkGitHubRepo() Check {
 	cfg := config.Load()
 
 	if cfg.Owner == "" || cfg.Repo == "" {
-		return
+		return Check{Name: "github-repo", Status: StatusWarn, Message: "GitHub repository not configured"}
 	}
 
 	exists, err := github.RepoExists(cfg.Owner, cfg.Repo)
 	if err != nil {
-		ui.Warn("Unable to check GitHub repository")
-		return
+		return warn("github-repo", "Unable to check GitHub repository")
 	}
 
 	if exists {
-		ui.Success("GitHub repository exists")
-	} else {
-		ui.Warn("GitHub repository does not exist")
-		ui.Info("Run Tools → Create / Link GitHub Repository")
+		return pass("github-repo", "GitHub repository exists")
 	}
+
+	return warn("github-repo", "GitHub repository does not exist",
+		"Run Tools → Create / Link GitHub Repository")
 }
 
-func checkErrorLog() {
+func checkErrorLog() Check {
 	cfg := config.Load()
 	logPath := filepath.Join(cfg.GetWorkDir(), ".git", ".genius", "error.log")
 
 	if _, err := os.Stat(logPath); err == nil {
-		ui.Warn("Error log exists")
-		ui.Info("Check: " + logPath)
-	} else {
-		ui.Success("No error log found")
+		return warn("error-log", "Error log exists",
+			"Check: "+logPath)
 	}
+
+	return pass("error-log", "No error log found")
 }
 
 /* ============================================================
@@ -230,12 +303,12 @@ func checkErrorLog() {
    ============================================================ */
 
 func gitConfig(dir, key string) string {
-	var out strings.Builder
-	cmd := system.GitCmdAt(dir, "config", "--get", key)
-	cmd.Stdout = &out
-
-	if err := cmd.Run(); err != nil {
+	resp, err := system.Git(system.Request{
+		Args: []string{"config", "--get", key},
+		Dir:  dir,
+	})
+	if err != nil {
 		return ""
 	}
-	return strings.TrimSpace(out.String())
+	return strings.TrimSpace(resp.Stdout)
 }
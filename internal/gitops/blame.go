@@ -0,0 +1,196 @@
+package gitops
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"git-genius/internal/system"
+)
+
+/* ============================================================
+   BLAME + FILE HISTORY (code archaeology)
+   ============================================================ */
+
+// BlameLine is one line of a file with the commit that last changed it
+type BlameLine struct {
+	Line     int       `json:"line"`
+	Hash     string    `json:"hash"`
+	Author   string    `json:"author"`
+	When     time.Time `json:"when"`
+	Summary  string    `json:"summary"`
+	OrigPath string    `json:"orig_path"` // file name in that commit (renames)
+	OrigLine int       `json:"orig_line"`
+	Text     string    `json:"text"`
+}
+
+// Committed reports false for lines that only exist in the work tree
+func (b BlameLine) Committed() bool {
+	return strings.Trim(b.Hash, "0") != ""
+}
+
+// FileRevision is one commit in the history of a file
+type FileRevision struct {
+	CommitSummary
+	Path    string `json:"path"`               // name of the file in this commit
+	OldPath string `json:"old_path,omitempty"` // previous name (renamed here)
+	Status  string `json:"status"`             // added, modified, renamed, deleted…
+}
+
+// TrackedFiles lists the files git knows, relative to the project directory
+func TrackedFiles() ([]string, error) {
+	out, err := system.GitRaw("ls-files", "-z")
+	if err != nil {
+		return nil, gitErr(err, out, "ls-files")
+	}
+
+	var files []string
+	for _, f := range strings.Split(out, "\x00") {
+		if f != "" {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// Blame annotates every line of path (work tree version)
+func Blame(path string) (Result, error) {
+	res := newResult("blame")
+
+	if !system.IsGitRepo() {
+		return res, ErrNotRepo
+	}
+
+	lines, err := ReadBlame(path)
+	if err != nil {
+		return res, err
+	}
+
+	res.Data = lines
+	res.addRef(path)
+	res.Summary = fmt.Sprintf("%s: %d line(s)", path, len(lines))
+	return res, nil
+}
+
+/*
+ReadBlame parses `git blame --porcelain`
+
+	<hash> <orig line> <final line> [<group size>]
+	author …             (only the first time a commit appears)
+	author-time …
+	summary …
+	filename …
+	<TAB><line text>
+*/
+func ReadBlame(path string) ([]BlameLine, error) {
+	args := []string{"blame", "--porcelain", "-M", "--", path}
+	out, err := system.GitRaw(args...)
+	if err != nil {
+		return nil, gitErr(err, out, args...)
+	}
+
+	type commitInfo struct {
+		author, summary, filename string
+		when                      time.Time
+	}
+	commits := map[string]*commitInfo{}
+
+	var lines []BlameLine
+	var cur BlameLine
+	var info *commitInfo
+
+	for _, l := range strings.Split(out, "\n") {
+		if text, ok := strings.CutPrefix(l, "\t"); ok && info != nil {
+			cur.Text = text
+			cur.Author, cur.When, cur.Summary, cur.OrigPath = info.author, info.when, info.summary, info.filename
+			lines = append(lines, cur)
+			continue
+		}
+
+		key, value, _ := strings.Cut(l, " ")
+
+		if len(key) == 40 || len(key) == 64 {
+			f := strings.Fields(value)
+			if len(f) < 2 {
+				continue
+			}
+			cur = BlameLine{Hash: key}
+			cur.OrigLine, _ = strconv.Atoi(f[0])
+			cur.Line, _ = strconv.Atoi(f[1])
+
+			if info = commits[key]; info == nil {
+				info = &commitInfo{}
+				commits[key] = info
+			}
+			continue
+		}
+		if info == nil {
+			continue
+		}
+
+		switch key {
+		case "author":
+			info.author = value
+		case "author-time":
+			info.when = unixTime(value)
+		case "summary":
+			info.summary = value
+		case "filename":
+			info.filename = value
+		}
+	}
+	return lines, nil
+}
+
+// FileHistory lists the commits that touched path, following renames
+func FileHistory(path string) (Result, error) {
+	res := newResult("file-history")
+
+	if !system.IsGitRepo() {
+		return res, ErrNotRepo
+	}
+
+	revs, err := ReadFileHistory(path)
+	if err != nil {
+		return res, err
+	}
+
+	res.Data = revs
+	res.addRef(path)
+	res.Summary = fmt.Sprintf("%s: %d commit(s)", path, len(revs))
+	return res, nil
+}
+
+// ReadFileHistory runs git log --follow with the name of the file in every commit
+func ReadFileHistory(path string) ([]FileRevision, error) {
+	args := []string{"log", "--follow", "-M", "--name-status",
+		"--format=" + logMarker + "%H%x1f%P%x1f%an%x1f%ae%x1f%ct%x1f%D%x1f%s", "--", path}
+	out, err := system.GitRaw(args...)
+	if err != nil {
+		return nil, gitErr(err, out, args...)
+	}
+
+	var revs []FileRevision
+	for _, l := range strings.Split(out, "\n") {
+		if rest, ok := strings.CutPrefix(l, logMarker); ok {
+			if c, ok := parseCommitSummary(rest); ok {
+				revs = append(revs, FileRevision{CommitSummary: c, Path: path})
+			}
+			continue
+		}
+
+		// A / M / D <TAB> path   or   R<score> <TAB> old <TAB> new
+		f := strings.Split(l, "\t")
+		if len(f) < 2 || len(revs) == 0 {
+			continue
+		}
+		r := &revs[len(revs)-1]
+		r.Status = ChangeLabel(f[0][:1])
+		r.Path = f[len(f)-1]
+		if len(f) == 3 {
+			r.OldPath = f[1]
+		}
+	}
+	return revs, nil
+}
@@ -0,0 +1,63 @@
+package gitops
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"git-genius/internal/system"
+)
+
+const (
+	blameCommit = "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+	blameWork   = "0000000000000000000000000000000000000000"
+)
+
+func TestReadBlame(t *testing.T) {
+	fake := useFake(t)
+	fake.On("blame --porcelain -M -- main.go", system.Response{Stdout: blameCommit + ` 1 1 2
+author Ada
+author-mail <ada@example.com>
+author-time 1700000000
+author-tz +0000
+summary add main
+filename cmd/main.go
+	package main
+` + blameCommit + ` 2 2
+	
+` + blameWork + ` 3 3 1
+author Not Committed Yet
+author-time 1700000500
+summary Version of main.go from main.go
+filename main.go
+	func main() {}
+`})
+
+	got, err := ReadBlame("main.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	when := time.Unix(1700000000, 0)
+	want := []BlameLine{
+		{Line: 1, Hash: blameCommit, Author: "Ada", When: when, Summary: "add main", OrigPath: "cmd/main.go", OrigLine: 1, Text: "package main"},
+		{Line: 2, Hash: blameCommit, Author: "Ada", When: when, Summary: "add main", OrigPath: "cmd/main.go", OrigLine: 2, Text: ""},
+		{Line: 3, Hash: blameWork, Author: "Not Committed Yet", When: time.Unix(1700000500, 0),
+			Summary: "Version of main.go from main.go", OrigPath: "main.go", OrigLine: 3, Text: "func main() {}"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadBlame()\n got %+v\nwant %+v", got, want)
+	}
+	if !got[0].Committed() || got[2].Committed() {
+		t.Error("Committed() must be false only for work tree lines")
+	}
+}
+
+func TestReadBlameError(t *testing.T) {
+	fake := useFake(t)
+	fake.On("blame", system.Response{Stderr: "fatal: no such path 'gone.go' in HEAD", ExitCode: 128})
+
+	if _, err := ReadBlame("gone.go"); err == nil {
+		t.Error("ReadBlame() on a failing git: want an error")
+	}
+}
@@ -1,62 +1,194 @@
 package gitops
 
 import (
+	"fmt"
+	"strings"
+
 	"git-genius/internal/config"
 	"git-genius/internal/system"
-	"git-genius/internal/ui"
 )
 
+/* ============================================================
+   BRANCH SWITCHING (never resets an existing branch)
+   ============================================================ */
+
+// Branch target kinds
+const (
+	BranchLocal  = "local"  // refs/heads/<name> exists
+	BranchRemote = "remote" // only <remote>/<name> exists → tracking branch
+	BranchNew    = "new"    // created from the current commit
+)
+
+// Dirty work tree handling when switching
+const (
+	DirtyRefuse = ""      // ErrDirtyWorkTree (frontend asks)
+	DirtyStash  = "stash" // stash changes, leave them on the stash list
+	DirtyCarry  = "carry" // take changes along (git refuses on conflicts)
+)
+
+// BranchTarget describes what switching to a name will do
+type BranchTarget struct {
+	Name     string `json:"name"`
+	Kind     string `json:"kind"`
+	Upstream string `json:"upstream,omitempty"` // remote-tracking ref (BranchRemote)
+}
+
 /*
-SwitchBranch switches (or creates) a branch
+ResolveBranch validates name (git check-ref-format) and finds what it refers to
+"origin/feature" resolves to local branch "feature" tracking origin/feature
 */
-func SwitchBranch() {
-	if !system.EnsureGitRepo() {
-		return
+func ResolveBranch(name string) (BranchTarget, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return BranchTarget{}, ErrInvalidArguments
 	}
 
-	name := ui.Input("New branch name")
-	if name == "" {
-		ui.Error("Branch name cannot be empty")
-		return
+	if refExists("refs/heads/" + name) {
+		return BranchTarget{Name: name, Kind: BranchLocal}, nil
+	}
+
+	// "<remote>/<branch>" typed explicitly
+	if refExists("refs/remotes/" + name) {
+		if _, local, ok := strings.Cut(name, "/"); ok && local != "" {
+			if refExists("refs/heads/" + local) {
+				return BranchTarget{Name: local, Kind: BranchLocal}, nil
+			}
+			return BranchTarget{Name: local, Kind: BranchRemote, Upstream: name}, nil
+		}
 	}
 
-	if err := system.RunGit("checkout", "-B", name); err != nil {
-		ui.Error("Failed to switch branch")
-		return
+	if !system.GitOK("check-ref-format", "--branch", name) {
+		return BranchTarget{}, fmt.Errorf("%w: %q", ErrInvalidBranchName, name)
 	}
 
+	// configured remote first, then any other remote
 	cfg := config.Load()
-	cfg.Branch = name
+	if cfg.Remote != "" && refExists("refs/remotes/"+cfg.Remote+"/"+name) {
+		return BranchTarget{Name: name, Kind: BranchRemote, Upstream: cfg.Remote + "/" + name}, nil
+	}
+
+	out, _ := system.GitRaw("for-each-ref", "--format=%(refname:short)", "refs/remotes/*/"+name)
+	if refs := splitLines(out); len(refs) > 0 {
+		return BranchTarget{Name: name, Kind: BranchRemote, Upstream: refs[0]}, nil
+	}
+
+	return BranchTarget{Name: name, Kind: BranchNew}, nil
+}
+
+func refExists(ref string) bool {
+	return system.GitOK("show-ref", "--verify", "--quiet", ref)
+}
+
+/*
+SwitchBranch switches to an existing local branch, creates a tracking
+branch for a remote one, or creates a new branch from the current commit
+Dirty work tree is handled according to dirty (DirtyRefuse / DirtyStash / DirtyCarry)
+*/
+func SwitchBranch(name, dirty string) (Result, error) {
+	return journaled("switch", func() (Result, error) { return switchBranch(name, dirty) })
+}
+
+func switchBranch(name, dirty string) (Result, error) {
+	res := newResult("switch")
+
+	if !system.IsGitRepo() {
+		return res, ErrNotRepo
+	}
+
+	if err := checkNoPending(); err != nil {
+		return res, err
+	}
+
+	target, err := ResolveBranch(name)
+	if err != nil {
+		return res, err
+	}
+
+	current := CurrentBranch()
+	if target.Kind == BranchLocal && target.Name == current {
+		res.addRef(current)
+		res.Summary = "Already on branch: " + current
+		return res, nil
+	}
+
+	// ---------- DIRTY WORK TREE ----------
+	stashed := false
+	if isWorkingTreeDirty() {
+		switch dirty {
+		case DirtyStash:
+			msg := "git-genius: switched from " + current + " to " + target.Name
+			if err := res.git("stash", "push", "--include-untracked", "-m", msg); err != nil {
+				return res, err
+			}
+			stashed = true
+			res.addRef("stash@{0}")
+			res.warn("Your changes were stashed (stash@{0}) on " + current)
+		case DirtyCarry:
+			res.warn("Uncommitted changes carried over to " + target.Name)
+		default:
+			return res, ErrDirtyWorkTree
+		}
+	}
+
+	// ---------- SWITCH ----------
+	var args []string
+	switch target.Kind {
+	case BranchLocal:
+		args = []string{"checkout", target.Name}
+	case BranchRemote:
+		args = []string{"checkout", "-b", target.Name, "--track", target.Upstream}
+	default:
+		args = []string{"checkout", "-b", target.Name}
+	}
+
+	if err := res.git(args...); err != nil {
+		if stashed && res.git("stash", "pop") != nil {
+			res.warn("Changes kept as stash@{0}")
+		}
+		return res, err
+	}
+
+	cfg := config.Load()
+	cfg.Branch = target.Name
 	config.Save(cfg)
 
-	ui.Success("Switched to branch: " + name)
+	res.addRef(target.Name, target.Upstream)
+	switch target.Kind {
+	case BranchLocal:
+		res.Summary = "Switched to branch: " + target.Name
+	case BranchRemote:
+		res.Summary = "Created branch " + target.Name + " tracking " + target.Upstream
+	default:
+		res.Summary = "Created new branch: " + target.Name
+	}
+	res.Data = target
+	return res, nil
 }
 
 /*
 SwitchRemote changes git remote
 */
-func SwitchRemote() {
-	if !system.EnsureGitRepo() {
-		return
-	}
+func SwitchRemote(name, url string) (Result, error) {
+	res := newResult("switch-remote")
 
-	name := ui.Input("Remote name")
-	url := ui.Input("Remote URL")
+	if !system.IsGitRepo() {
+		return res, ErrNotRepo
+	}
 
 	if name == "" || url == "" {
-		ui.Error("Remote name and URL are required")
-		return
+		return res, ErrInvalidArguments
 	}
 
-	_ = system.RunGit("remote", "remove", name)
-	if err := system.RunGit("remote", "add", name, url); err != nil {
-		ui.Error("Failed to add remote")
-		return
+	_, _ = system.GitCombined("remote", "remove", name)
+	if err := res.git("remote", "add", name, url); err != nil {
+		return res, err
 	}
 
 	cfg := config.Load()
 	cfg.Remote = name
 	config.Save(cfg)
 
-	ui.Success("Remote updated: " + name)
+	res.addRef(name)
+	res.Summary = "Remote updated: " + name
+	return res, nil
 }
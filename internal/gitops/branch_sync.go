@@ -5,44 +5,25 @@ import (
 
 	"git-genius/internal/config"
 	"git-genius/internal/system"
-	"git-genius/internal/ui"
 )
 
 /*
-EnsureBranchSync keeps config branch and actual git branch in sync
+BranchMismatch compares config branch and actual git branch
 Handles master/main mismatch cleanly
+No commits yet → never a mismatch
 */
-func EnsureBranchSync() {
-	if !system.IsGitRepo() {
-		return
-	}
-
+func BranchMismatch() (current, configured string, mismatch bool) {
 	cfg := config.Load()
 
-	current, err := system.GitOutput("branch", "--show-current")
-	if err != nil || current == "" {
-		// No commits yet → nothing to sync
-		return
-	}
-
-	current = strings.TrimSpace(current)
-
-	// Already synced
-	if current == cfg.Branch {
-		return
+	if !system.IsGitRepo() {
+		return "", cfg.Branch, false
 	}
 
-	ui.Warn("Branch mismatch detected")
-	ui.Info("Git branch    : " + current)
-	ui.Info("Config branch : " + cfg.Branch)
-
-	if !ui.Confirm("Sync config to current git branch?") {
-		ui.Warn("Keeping config branch unchanged")
-		return
+	out, err := system.GitOutput("branch", "--show-current")
+	if err != nil || out == "" {
+		return "", cfg.Branch, false
 	}
 
-	cfg.Branch = current
-	config.Save(cfg)
-
-	ui.Success("Branch synced to: " + current)
+	current = strings.TrimSpace(out)
+	return current, cfg.Branch, current != cfg.Branch
 }
@@ -0,0 +1,80 @@
+package gitops
+
+import (
+	"errors"
+	"testing"
+
+	"git-genius/internal/config"
+	"git-genius/internal/system"
+)
+
+func TestResolveBranch(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		remote  string   // config.Remote
+		refs    []string // existing refs
+		remotes string   // for-each-ref refs/remotes/*/<name> output
+		want    BranchTarget
+		wantErr error
+	}{
+		{
+			name: "local branch", input: " feature ", remote: "origin",
+			refs: []string{"refs/heads/feature", "refs/remotes/origin/feature"},
+			want: BranchTarget{Name: "feature", Kind: BranchLocal},
+		},
+		{
+			name: "remote branch typed with its remote", input: "origin/feature", remote: "origin",
+			refs: []string{"refs/remotes/origin/feature"},
+			want: BranchTarget{Name: "feature", Kind: BranchRemote, Upstream: "origin/feature"},
+		},
+		{
+			name: "remote name typed, local branch exists", input: "origin/feature", remote: "origin",
+			refs: []string{"refs/heads/feature", "refs/remotes/origin/feature"},
+			want: BranchTarget{Name: "feature", Kind: BranchLocal},
+		},
+		{
+			name: "configured remote wins", input: "feature", remote: "upstream",
+			refs:    []string{"refs/remotes/origin/feature", "refs/remotes/upstream/feature"},
+			remotes: "origin/feature\nupstream/feature\n",
+			want:    BranchTarget{Name: "feature", Kind: BranchRemote, Upstream: "upstream/feature"},
+		},
+		{
+			name: "ambiguous on other remotes: first one", input: "feature", remote: "origin",
+			refs:    []string{"refs/remotes/fork/feature", "refs/remotes/upstream/feature"},
+			remotes: "fork/feature\nupstream/feature\n",
+			want:    BranchTarget{Name: "feature", Kind: BranchRemote, Upstream: "fork/feature"},
+		},
+		{
+			name: "new branch", input: "feature", remote: "origin",
+			want: BranchTarget{Name: "feature", Kind: BranchNew},
+		},
+		{name: "invalid name", input: "bad..name", remote: "origin", wantErr: ErrInvalidBranchName},
+		{name: "empty", input: "  ", wantErr: ErrInvalidArguments},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chdirTemp(t)
+			cfg := config.Load()
+			cfg.Remote = tt.remote
+			config.Save(cfg)
+
+			fake := useFake(t)
+			fake.On("show-ref --verify --quiet", system.Response{ExitCode: 1})
+			for _, ref := range tt.refs {
+				fake.On("show-ref --verify --quiet "+ref, system.Response{})
+			}
+			fake.On("check-ref-format --branch bad..name", system.Response{ExitCode: 1})
+			fake.On("for-each-ref --format=%(refname:short) refs/remotes/*/feature", system.Response{Stdout: tt.remotes})
+
+			got, err := ResolveBranch(tt.input)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil) != (err == nil) {
+				t.Fatalf("ResolveBranch(%q) error = %v, want %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveBranch(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,325 @@
+package gitops
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"git-genius/internal/config"
+	"git-genius/internal/system"
+)
+
+/* ============================================================
+   BRANCH MANAGER
+   ============================================================ */
+
+// BranchInfo is one local or remote-tracking branch
+type BranchInfo struct {
+	Name       string    `json:"name"` // "feature" or "origin/feature"
+	Remote     bool      `json:"remote"`
+	Current    bool      `json:"current"`
+	Hash       string    `json:"hash"`
+	Subject    string    `json:"subject"`
+	LastCommit time.Time `json:"last_commit"`
+	Upstream   string    `json:"upstream,omitempty"`
+	Ahead      int       `json:"ahead"`
+	Behind     int       `json:"behind"`
+	Gone       bool      `json:"gone,omitempty"`   // upstream deleted on the remote
+	Merged     bool      `json:"merged,omitempty"` // merged into config.DefaultBranch
+}
+
+// Branches lists local branches, then remote-tracking ones
+func Branches() (Result, error) {
+	res := newResult("branches")
+
+	if !system.IsGitRepo() {
+		return res, ErrNotRepo
+	}
+
+	list, err := ListBranches()
+	if err != nil {
+		return res, err
+	}
+
+	res.Data = list
+	res.addRef(config.Load().DefaultBranch)
+	return res, nil
+}
+
+// ListBranches reads branch details with one for-each-ref call
+func ListBranches() ([]BranchInfo, error) {
+	out, err := system.GitRaw("for-each-ref", "--sort=-committerdate",
+		"--format=%(refname)%1f%(objectname)%1f%(upstream:short)%1f%(upstream:track,nobracket)%1f%(committerdate:unix)%1f%(HEAD)%1f%(subject)",
+		"refs/heads", "refs/remotes")
+	if err != nil {
+		return nil, err
+	}
+
+	merged := mergedRefs(mergeBase())
+
+	var local, remote []BranchInfo
+	for _, l := range strings.Split(out, "\n") {
+		f := strings.Split(l, fieldSep)
+		if len(f) != 7 || strings.HasSuffix(f[0], "/HEAD") {
+			continue
+		}
+
+		b := BranchInfo{
+			Hash:       f[1],
+			Upstream:   f[2],
+			LastCommit: unixTime(f[4]),
+			Current:    f[5] == "*",
+			Subject:    f[6],
+			Merged:     merged[f[0]],
+		}
+		b.Ahead, b.Behind, b.Gone = parseTrack(f[3])
+
+		if name, ok := strings.CutPrefix(f[0], "refs/heads/"); ok {
+			b.Name = name
+			local = append(local, b)
+		} else {
+			b.Name = strings.TrimPrefix(f[0], "refs/remotes/")
+			b.Remote = true
+			remote = append(remote, b)
+		}
+	}
+	return append(local, remote...), nil
+}
+
+// parseTrack reads "ahead 1, behind 2" / "gone"
+func parseTrack(s string) (ahead, behind int, gone bool) {
+	if s == "gone" {
+		return 0, 0, true
+	}
+	for _, part := range strings.Split(s, ", ") {
+		if n, ok := strings.CutPrefix(part, "ahead "); ok {
+			ahead, _ = strconv.Atoi(n)
+		}
+		if n, ok := strings.CutPrefix(part, "behind "); ok {
+			behind, _ = strconv.Atoi(n)
+		}
+	}
+	return ahead, behind, false
+}
+
+/*
+mergeBase is the ref "merged" is measured against:
+config.DefaultBranch, or its remote copy when there is no local branch
+*/
+func mergeBase() string {
+	cfg := config.Load()
+	if refExists("refs/heads/" + cfg.DefaultBranch) {
+		return cfg.DefaultBranch
+	}
+	if ref := cfg.Remote + "/" + cfg.DefaultBranch; refExists("refs/remotes/" + ref) {
+		return ref
+	}
+	return ""
+}
+
+// mergedRefs returns full ref names merged into base
+func mergedRefs(base string) map[string]bool {
+	merged := map[string]bool{}
+	if base == "" {
+		return merged
+	}
+
+	out, err := system.GitRaw("for-each-ref", "--merged="+base, "--format=%(refname)", "refs/heads", "refs/remotes")
+	if err != nil {
+		return merged
+	}
+	for _, ref := range splitLines(out) {
+		merged[ref] = true
+	}
+	return merged
+}
+
+/* ============================================================
+   ACTIONS
+   ============================================================ */
+
+// RenameBranch renames a local branch (config branch / default branch follow)
+func RenameBranch(old, name string) (Result, error) {
+	return journaled("rename-branch", func() (Result, error) { return renameBranch(old, name) })
+}
+
+func renameBranch(old, name string) (Result, error) {
+	res := newResult("rename-branch")
+
+	if old == "" || name == "" {
+		return res, ErrInvalidArguments
+	}
+	if !system.GitOK("check-ref-format", "--branch", name) {
+		return res, fmt.Errorf("%w: %q", ErrInvalidBranchName, name)
+	}
+	if refExists("refs/heads/" + name) {
+		return res, fmt.Errorf("%w: branch %s already exists", ErrInvalidArguments, name)
+	}
+
+	if err := res.git("branch", "-m", old, name); err != nil {
+		return res, err
+	}
+
+	followRename(old, name)
+
+	res.addRef(name)
+	res.Summary = "Renamed " + old + " → " + name
+	return res, nil
+}
+
+// followRename points the config branch / default branch at the new name
+func followRename(old, name string) {
+	cfg := config.Load()
+	if cfg.Branch != old && cfg.DefaultBranch != old {
+		return
+	}
+	if cfg.Branch == old {
+		cfg.Branch = name
+	}
+	if cfg.DefaultBranch == old {
+		cfg.DefaultBranch = name
+	}
+	config.Save(cfg)
+}
+
+/*
+DeleteBranch deletes a local branch
+Not merged into config.DefaultBranch + !force → ErrNotMerged
+force saves the branch under refs/genius/backup/ first
+The current, default and config branch are refused
+*/
+func DeleteBranch(name string, force bool) (Result, error) {
+	return journaled("delete-branch", func() (Result, error) { return deleteBranch(name, force) })
+}
+
+func deleteBranch(name string, force bool) (Result, error) {
+	res := newResult("delete-branch")
+	cfg := config.Load()
+
+	switch {
+	case name == "":
+		return res, ErrInvalidArguments
+	case name == CurrentBranch():
+		return res, fmt.Errorf("%w: %s is checked out", ErrProtectedBranch, name)
+	case name == cfg.DefaultBranch:
+		return res, fmt.Errorf("%w: %s is the default branch", ErrProtectedBranch, name)
+	case name == cfg.Branch:
+		return res, fmt.Errorf("%w: %s is the config branch", ErrProtectedBranch, name)
+	}
+
+	if !force && !mergedRefs(mergeBase())["refs/heads/"+name] {
+		return res, fmt.Errorf("%w: %s", ErrNotMerged, name)
+	}
+
+	if force {
+		backup, err := createBackupAt("delete-"+strings.ReplaceAll(name, "/", "-"), "refs/heads/"+name)
+		if err != nil {
+			return res, err
+		}
+		res.addRef(backup)
+	}
+
+	hash, _ := system.GitRaw("log", "-1", "--format=%h", "refs/heads/"+name)
+	if err := res.git("branch", "-D", name); err != nil {
+		return res, err
+	}
+
+	res.addRef(name)
+	res.Summary = "Deleted branch " + name + " (was " + strings.TrimSpace(hash) + ")"
+	return res, nil
+}
+
+/*
+SetUpstream makes name track upstream ("origin/name")
+The remote branch must exist (see PublishBranch)
+*/
+func SetUpstream(name, upstream string) (Result, error) {
+	res := newResult("set-upstream")
+
+	if name == "" || upstream == "" {
+		return res, ErrInvalidArguments
+	}
+	if !refExists("refs/remotes/" + upstream) {
+		return res, fmt.Errorf("%w: %s does not exist", ErrInvalidArguments, upstream)
+	}
+
+	if err := res.git("branch", "--set-upstream-to="+upstream, name); err != nil {
+		return res, err
+	}
+
+	res.addRef(name, upstream)
+	res.Summary = name + " now tracks " + upstream
+	return res, nil
+}
+
+/*
+PublishBranch pushes name to cfg.Remote and tracks it
+Its commits pass the secret scan and the large file check first
+(ErrSecretsDetected / ErrLargeFiles, details in Result.Data)
+*/
+func PublishBranch(name string) (Result, error) {
+	res := newResult("publish-branch")
+	cfg := config.Load()
+
+	if cfg.Remote == "" {
+		return res, ErrNoRemote
+	}
+
+	if err := res.checkOutgoing(cfg.Remote, "refs/heads/"+name); err != nil {
+		return res, err
+	}
+
+	if err := res.git("push", "-u", cfg.Remote, name); err != nil {
+		return res, err
+	}
+
+	res.addRef(name, cfg.Remote+"/"+name)
+	res.Summary = name + " pushed and tracking " + cfg.Remote + "/" + name
+	return res, nil
+}
+
+/*
+MergedBranches lists local branches safe to clean up:
+merged into config.DefaultBranch, not current / default / config branch
+*/
+func MergedBranches() ([]string, error) {
+	list, err := ListBranches()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := config.Load()
+	var names []string
+	for _, b := range list {
+		if b.Remote || !b.Merged || b.Current || b.Name == cfg.DefaultBranch || b.Name == cfg.Branch {
+			continue
+		}
+		names = append(names, b.Name)
+	}
+	return names, nil
+}
+
+// CleanupBranches deletes the given merged branches (merge check repeated)
+func CleanupBranches(names []string) (Result, error) {
+	return journaled("cleanup-branches", func() (Result, error) { return cleanupBranches(names) })
+}
+
+func cleanupBranches(names []string) (Result, error) {
+	res := newResult("cleanup-branches")
+
+	deleted := 0
+	for _, name := range names {
+		r, err := deleteBranch(name, false)
+		if err != nil {
+			res.warn("Skipped " + name + ": " + err.Error())
+			continue
+		}
+		res.addRef(name)
+		res.Output = strings.TrimSpace(res.Output + "\n" + r.Summary)
+		deleted++
+	}
+
+	res.Summary = fmt.Sprintf("Deleted %d merged branch(es)", deleted)
+	return res, nil
+}
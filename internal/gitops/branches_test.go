@@ -0,0 +1,176 @@
+package gitops
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"git-genius/internal/config"
+	"git-genius/internal/system"
+)
+
+func TestDeleteBranchRefusesProtected(t *testing.T) {
+	tests := []struct {
+		name   string
+		branch string
+		want   error
+	}{
+		{"checked out", "dev", ErrProtectedBranch},
+		{"default branch", "main", ErrProtectedBranch},
+		{"configured branch", "release", ErrProtectedBranch},
+		{"no name", "", ErrInvalidArguments},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chdirTemp(t)
+			cfg := config.Load()
+			cfg.DefaultBranch, cfg.Branch = "main", "release"
+			config.Save(cfg)
+
+			fake := useFake(t)
+			fake.On("branch --show-current", system.Response{Stdout: "dev\n"})
+
+			if _, err := DeleteBranch(tt.branch, true); !errors.Is(err, tt.want) {
+				t.Errorf("DeleteBranch(%q) error = %v, want %v", tt.branch, err, tt.want)
+			}
+			for _, c := range fake.Commands() {
+				if strings.HasPrefix(c, "branch -D") {
+					t.Errorf("ran %q", c)
+				}
+			}
+		})
+	}
+}
+
+// branchRepo is a journalRepo whose config names the checked out branch
+func branchRepo(t *testing.T) (write func(name, content string), git func(args ...string) string, branch string) {
+	t.Helper()
+
+	write, _, git = journalRepo(t)
+	branch = strings.TrimSpace(git("branch", "--show-current"))
+
+	cfg := config.Load()
+	cfg.Branch, cfg.DefaultBranch, cfg.Remote = branch, branch, "origin"
+	config.Save(cfg)
+	return write, git, branch
+}
+
+func TestDeleteBranchForceKeepsBackup(t *testing.T) {
+	write, git, branch := branchRepo(t)
+
+	git("checkout", "-q", "-b", "topic")
+	write("a.txt", "topic\n")
+	git("commit", "-q", "-am", "topic work")
+	topic := strings.TrimSpace(git("rev-parse", "topic"))
+	git("checkout", "-q", branch)
+
+	if _, err := DeleteBranch("topic", false); !errors.Is(err, ErrNotMerged) {
+		t.Fatalf("DeleteBranch(force=false) error = %v, want ErrNotMerged", err)
+	}
+	if _, err := DeleteBranch("topic", true); err != nil {
+		t.Fatal(err)
+	}
+
+	backups, err := Backups()
+	if err != nil || len(backups) != 1 || backups[0].Hash != topic {
+		t.Fatalf("Backups() = %+v, %v; want one backup of %s", backups, err, topic)
+	}
+
+	if _, err := UndoOperation(false); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(git("rev-parse", "topic")); got != topic {
+		t.Errorf("topic after undo = %s, want %s", got, topic)
+	}
+}
+
+func TestUndoRenameBranch(t *testing.T) {
+	_, git, branch := branchRepo(t)
+	head := strings.TrimSpace(git("rev-parse", "HEAD"))
+
+	if _, err := RenameBranch(branch, "trunk"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg := config.Load(); cfg.Branch != "trunk" || cfg.DefaultBranch != "trunk" {
+		t.Fatalf("config after rename = %q / %q, want trunk", cfg.Branch, cfg.DefaultBranch)
+	}
+
+	if _, err := UndoOperation(false); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := CurrentBranch(); got != branch {
+		t.Errorf("branch = %q, want %q", got, branch)
+	}
+	if got := strings.TrimSpace(git("rev-parse", branch)); got != head {
+		t.Errorf("%s = %s, want %s", branch, got, head)
+	}
+	if got := git("branch", "--list", "trunk"); got != "" {
+		t.Errorf("trunk still exists: %q", got)
+	}
+	if cfg := config.Load(); cfg.Branch != branch || cfg.DefaultBranch != branch {
+		t.Errorf("config after undo = %q / %q, want %q", cfg.Branch, cfg.DefaultBranch, branch)
+	}
+}
+
+func TestUndoCleanupBranches(t *testing.T) {
+	_, git, _ := branchRepo(t)
+	git("branch", "done-1")
+	git("branch", "done-2")
+
+	res, err := CleanupBranches([]string{"done-1", "done-2"})
+	if err != nil || len(res.Warnings) > 0 {
+		t.Fatalf("CleanupBranches() = %+v, %v", res, err)
+	}
+	if _, err := UndoOperation(false); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Fields(git("branch", "--list", "done-*", "--format=%(refname:short)")); strings.Join(got, " ") != "done-1 done-2" {
+		t.Errorf("branches after undo = %q, want done-1 done-2", got)
+	}
+}
+
+func TestPublishBranchChecksOutgoing(t *testing.T) {
+	tests := []struct {
+		name  string
+		file  string
+		data  string
+		limit int
+		want  error
+	}{
+		{"secret", "main.go", "token := \"ghp_" + "abcdefghijklmnopqrstuvwxyz0123456789\"\n", 0, ErrSecretsDetected},
+		{"large file", "data.bin", strings.Repeat("x", 2<<20), 1, ErrLargeFiles},
+		{"clean", "main.go", "package main\n", 0, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			write, git, branch := branchRepo(t)
+			if tt.limit > 0 {
+				cfg := config.Load()
+				cfg.LargeFileMB = tt.limit
+				config.Save(cfg)
+			}
+
+			remote := t.TempDir()
+			git("init", "-q", "--bare", remote)
+			git("remote", "add", "origin", remote)
+			git("push", "-q", "origin", branch)
+
+			git("checkout", "-q", "-b", "topic")
+			write(tt.file, tt.data)
+			git("add", ".")
+			git("commit", "-q", "-m", "topic work")
+
+			_, err := PublishBranch("topic")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("PublishBranch() error = %v, want %v", err, tt.want)
+			}
+			pushed := git("ls-remote", "--heads", remote, "topic") != ""
+			if pushed != (tt.want == nil) {
+				t.Errorf("topic on the remote = %v, want %v", pushed, tt.want == nil)
+			}
+		})
+	}
+}
@@ -0,0 +1,318 @@
+package gitops
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"git-genius/internal/config"
+	"git-genius/internal/semver"
+	"git-genius/internal/system"
+)
+
+/* ============================================================
+   CHANGELOG (Markdown from Conventional Commits)
+   ============================================================ */
+
+// ChangelogFile is created / updated in the project directory
+const ChangelogFile = "CHANGELOG.md"
+
+// Changelog is one generated release section
+type Changelog struct {
+	From     string `json:"from,omitempty"` // "" = from the first commit
+	To       string `json:"to"`
+	Title    string `json:"title"`
+	Commits  int    `json:"commits"`
+	Markdown string `json:"markdown"`
+}
+
+// changelog sections in output order (other types go to "Other Changes")
+var changelogTypes = []struct{ kind, title string }{
+	{"feat", "Features"},
+	{"fix", "Bug Fixes"},
+	{"perf", "Performance Improvements"},
+	{"revert", "Reverts"},
+	{"refactor", "Code Refactoring"},
+	{"docs", "Documentation"},
+	{"style", "Styles"},
+	{"test", "Tests"},
+	{"build", "Build System"},
+	{"ci", "Continuous Integration"},
+	{"chore", "Chores"},
+}
+
+// #12 at the start of a word, e.g. "fix crash (#12)"
+var issueRe = regexp.MustCompile(`(^|[\s(\[,])#(\d+)\b`)
+
+/*
+PreviousVersionTag is the version tag a changelog for to starts from:
+the latest one reachable from to, skipping a tag on to itself
+*/
+func PreviousVersionTag(to string) string {
+	tag, _, ok := LatestVersionTag(to)
+	if !ok {
+		return ""
+	}
+	if revCommit(tag) == revCommit(to) {
+		tag, _, _ = LatestVersionTag(to + "^")
+	}
+	return tag
+}
+
+func revCommit(rev string) string {
+	out, _ := system.GitRaw("rev-parse", "--verify", "--quiet", rev+"^{commit}")
+	return strings.TrimSpace(out)
+}
+
+/*
+BuildChangelog groups the commits in from..to by type
+from "" = previous version tag (or the first commit), to "" = HEAD,
+title "" = to when it is a tag, otherwise "Unreleased"
+*/
+func BuildChangelog(from, to, title string) (Changelog, error) {
+	if to == "" {
+		to = "HEAD"
+	}
+	if revCommit(to) == "" {
+		return Changelog{}, fmt.Errorf("%w: unknown revision %q", ErrInvalidArguments, to)
+	}
+	if from == "" {
+		from = PreviousVersionTag(to)
+	} else if revCommit(from) == "" {
+		return Changelog{}, fmt.Errorf("%w: unknown revision %q", ErrInvalidArguments, from)
+	}
+	if title == "" {
+		title = "Unreleased"
+		if refExists("refs/tags/" + to) {
+			title = to
+		}
+	}
+
+	all, err := CommitsBetween(from, to)
+	if err != nil {
+		return Changelog{}, err
+	}
+
+	// earlier changelog updates are not news
+	var commits []ReleaseCommit
+	for _, c := range all {
+		if !(c.Message.Type == "docs" && c.Message.Scope == "changelog") {
+			commits = append(commits, c)
+		}
+	}
+	if len(commits) == 0 {
+		return Changelog{}, ErrNoCommits
+	}
+
+	date := time.Now()
+	if to != "HEAD" {
+		if out, err := system.GitRaw("log", "-1", "--format=%ct", to); err == nil {
+			date = unixTime(strings.TrimSpace(out))
+		}
+	}
+
+	cl := Changelog{From: from, To: to, Title: title, Commits: len(commits)}
+	cl.Markdown = renderChangelog(cl, commits, date)
+	return cl, nil
+}
+
+// GenerateChangelog builds the Markdown section (Result.Data = Changelog)
+func GenerateChangelog(from, to, title string) (Result, error) {
+	res := newResult("changelog")
+
+	if !system.IsGitRepo() {
+		return res, ErrNotRepo
+	}
+	if !hasAnyCommit() {
+		return res, ErrNoCommits
+	}
+
+	cl, err := BuildChangelog(from, to, title)
+	if err != nil {
+		return res, err
+	}
+
+	res.Data = cl
+	from = cl.From
+	if from == "" {
+		from = "first commit"
+	}
+	res.Summary = fmt.Sprintf("Changelog %s: %d commit(s) since %s", cl.Title, cl.Commits, from)
+	return res, nil
+}
+
+// repoURL is https://github.com/<Owner>/<Repo>, "" when not configured
+func repoURL() string {
+	cfg := config.Load()
+	if cfg.Owner == "" || cfg.Repo == "" {
+		return ""
+	}
+	return "https://github.com/" + cfg.Owner + "/" + cfg.Repo
+}
+
+func renderChangelog(cl Changelog, commits []ReleaseCommit, date time.Time) string {
+	base := repoURL()
+	var b strings.Builder
+
+	heading := cl.Title
+	if base != "" && cl.From != "" {
+		target := cl.To
+		if _, ok := semver.Parse(cl.Title); ok {
+			target = cl.Title // compare link works once the tag exists
+		}
+		heading = fmt.Sprintf("[%s](%s/compare/%s...%s)", cl.Title, base, cl.From, target)
+	}
+	fmt.Fprintf(&b, "## %s (%s)\n", heading, date.Format("2006-01-02"))
+
+	var breaking []string
+	groups := map[string][]string{}
+	for _, c := range commits {
+		kind, line := "", c.Subject
+		if c.Conventional {
+			kind, line = c.Message.Type, c.Message.Subject
+			if c.Message.Scope != "" {
+				line = "**" + c.Message.Scope + ":** " + line
+			}
+		}
+		entry := changelogEntry(line, c, base)
+
+		if !knownChangelogType(kind) {
+			kind = ""
+		}
+		groups[kind] = append(groups[kind], entry)
+
+		if c.Conventional && c.Message.Breaking {
+			note := line
+			for _, f := range c.Message.Footers {
+				if text, ok := strings.CutPrefix(f, "BREAKING CHANGE: "); ok {
+					note = text
+				}
+			}
+			breaking = append(breaking, changelogEntry(note, c, base))
+		}
+	}
+
+	writeSection := func(title string, entries []string) {
+		if len(entries) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n### %s\n\n", title)
+		for _, e := range entries {
+			b.WriteString("* " + e + "\n")
+		}
+	}
+
+	writeSection("⚠ BREAKING CHANGES", breaking)
+	for _, t := range changelogTypes {
+		writeSection(t.title, groups[t.kind])
+	}
+	writeSection("Other Changes", groups[""])
+	return b.String()
+}
+
+func knownChangelogType(kind string) bool {
+	for _, t := range changelogTypes {
+		if t.kind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// changelogEntry links #numbers, adds footer references and the commit
+func changelogEntry(text string, c ReleaseCommit, base string) string {
+	refs := map[string]bool{}
+	for _, m := range issueRe.FindAllStringSubmatch(text, -1) {
+		refs[m[2]] = true
+	}
+
+	// Refs: #12, Closes #3 …
+	var extra []string
+	for _, f := range c.Message.Footers {
+		for _, m := range issueRe.FindAllStringSubmatch(f, -1) {
+			if !refs[m[2]] {
+				refs[m[2]] = true
+				extra = append(extra, "#"+m[2])
+			}
+		}
+	}
+	if len(extra) > 0 {
+		text += " (" + strings.Join(extra, ", ") + ")"
+	}
+
+	hash := shortHash(c.Hash)
+	if base == "" {
+		return text + " (" + hash + ")"
+	}
+	text = issueRe.ReplaceAllString(text, "${1}[#${2}]("+base+"/issues/${2})")
+	return fmt.Sprintf("%s ([%s](%s/commit/%s))", text, hash, base, c.Hash)
+}
+
+/*
+PrependChangelog puts section at the top of CHANGELOG.md (below its
+"# …" title), creating the file if needed, and optionally commits it
+Only CHANGELOG.md is committed; other staged changes stay staged
+*/
+func PrependChangelog(cl Changelog, commit bool) (Result, error) {
+	return journaled("changelog", func() (Result, error) { return prependChangelog(cl, commit) })
+}
+
+func prependChangelog(cl Changelog, commit bool) (Result, error) {
+	res := newResult("update-changelog")
+
+	if !system.IsGitRepo() {
+		return res, ErrNotRepo
+	}
+	if commit {
+		if err := checkNoPending(); err != nil {
+			return res, err
+		}
+	}
+
+	file := filepath.Join(config.Load().GetWorkDir(), ChangelogFile)
+	existing, err := os.ReadFile(file)
+	if err != nil && !os.IsNotExist(err) {
+		return res, err
+	}
+
+	if strings.Contains(string(existing), "## "+cl.Title+" ") ||
+		strings.Contains(string(existing), "## ["+cl.Title+"]") {
+		res.warn(ChangelogFile + " already has a section for " + cl.Title)
+	}
+
+	title, rest := "# Changelog\n", string(existing)
+	if strings.HasPrefix(rest, "# ") {
+		title, rest, _ = strings.Cut(rest, "\n")
+		title += "\n"
+	}
+	rest = strings.TrimLeft(rest, "\n")
+
+	content := title + "\n" + strings.TrimRight(cl.Markdown, "\n") + "\n"
+	if rest != "" {
+		content += "\n" + rest
+	}
+
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		return res, err
+	}
+	res.addRef(ChangelogFile)
+
+	if !commit {
+		res.Summary = "Updated " + ChangelogFile + " (not committed)"
+		return res, nil
+	}
+
+	if err := res.git("add", "--", ChangelogFile); err != nil {
+		return res, err
+	}
+	msg := "docs(changelog): " + cl.Title // skipped by the next changelog
+	if err := res.git("commit", "-q", "-m", msg, "--", ChangelogFile); err != nil {
+		return res, err
+	}
+
+	res.Summary = "Updated and committed " + ChangelogFile
+	return res, nil
+}
@@ -0,0 +1,97 @@
+package gitops
+
+import (
+	"testing"
+	"time"
+
+	"git-genius/internal/commitmsg"
+	"git-genius/internal/config"
+)
+
+func releaseCommit(hash, raw string) ReleaseCommit {
+	msg, ok := commitmsg.Parse(raw)
+	return ReleaseCommit{Hash: hash, Subject: msg.Subject, Message: msg, Conventional: ok}
+}
+
+func TestRenderChangelog(t *testing.T) {
+	commits := []ReleaseCommit{
+		releaseCommit("1111111aaaa", "feat(api)!: drop v1 endpoints\n\nBREAKING CHANGE: /v1 is gone"),
+		releaseCommit("2222222bbbb", "fix: crash on empty input (#12)"),
+		releaseCommit("3333333cccc", "feat: dark mode\n\nRefs: #7"),
+		releaseCommit("4444444dddd", "update readme"),
+		releaseCommit("5555555eeee", "wip(x): unknown type"),
+	}
+	date := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		owner string
+		cl    Changelog
+		want  string
+	}{
+		{
+			name: "without repository links",
+			cl:   Changelog{From: "v1.0.0", To: "HEAD", Title: "v2.0.0"},
+			want: `## v2.0.0 (2024-03-01)
+
+### ⚠ BREAKING CHANGES
+
+* /v1 is gone (1111111)
+
+### Features
+
+* **api:** drop v1 endpoints (1111111)
+* dark mode (#7) (3333333)
+
+### Bug Fixes
+
+* crash on empty input (#12) (2222222)
+
+### Other Changes
+
+* update readme (4444444)
+* **x:** unknown type (5555555)
+`,
+		},
+		{
+			name:  "with GitHub links",
+			owner: "octo",
+			cl:    Changelog{From: "v1.0.0", To: "HEAD", Title: "v2.0.0"},
+			want: `## [v2.0.0](https://github.com/octo/app/compare/v1.0.0...v2.0.0) (2024-03-01)
+
+### ⚠ BREAKING CHANGES
+
+* /v1 is gone ([1111111](https://github.com/octo/app/commit/1111111aaaa))
+
+### Features
+
+* **api:** drop v1 endpoints ([1111111](https://github.com/octo/app/commit/1111111aaaa))
+* dark mode ([#7](https://github.com/octo/app/issues/7)) ([3333333](https://github.com/octo/app/commit/3333333cccc))
+
+### Bug Fixes
+
+* crash on empty input ([#12](https://github.com/octo/app/issues/12)) ([2222222](https://github.com/octo/app/commit/2222222bbbb))
+
+### Other Changes
+
+* update readme ([4444444](https://github.com/octo/app/commit/4444444dddd))
+* **x:** unknown type ([5555555](https://github.com/octo/app/commit/5555555eeee))
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chdirTemp(t)
+			if tt.owner != "" {
+				cfg := config.Load()
+				cfg.Owner, cfg.Repo = tt.owner, "app"
+				config.Save(cfg)
+			}
+
+			if got := renderChangelog(tt.cl, commits, date); got != tt.want {
+				t.Errorf("renderChangelog()\n got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,271 @@
+package gitops
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"git-genius/internal/config"
+	"git-genius/internal/system"
+)
+
+/* ============================================================
+   CONFLICT REGIONS
+   ============================================================
+
+   <<<<<<< ours-label
+   ours
+   ||||||| base          (diff3 / zdiff3 only)
+   base
+   =======
+   theirs
+   >>>>>>> theirs-label
+*/
+
+// Conflict resolutions
+const (
+	PickNone   = ""       // keep the markers
+	PickOurs   = "ours"   // current branch
+	PickTheirs = "theirs" // incoming branch
+	PickBoth   = "both"   // ours, then theirs
+)
+
+// Conflict is one marked region
+type Conflict struct {
+	OursLabel   string
+	TheirsLabel string
+	Ours        []string
+	Base        []string
+	Theirs      []string
+	Pick        string
+}
+
+// segment is plain text or a conflict
+type segment struct {
+	text     []string
+	conflict *Conflict
+}
+
+// ConflictFile is a conflicted file split into regions
+type ConflictFile struct {
+	Path      string
+	Conflicts []*Conflict
+	segments  []segment
+	crlf      bool
+}
+
+// conflictMarker reports "<<<<<<<"-style lines (7 chars + space / end)
+func conflictMarker(line, marker string) bool {
+	return line == marker || strings.HasPrefix(line, marker+" ")
+}
+
+// ReadConflicts parses the conflict markers of path (relative to the work dir)
+func ReadConflicts(path string) (ConflictFile, error) {
+	cf := ConflictFile{Path: path}
+
+	data, err := os.ReadFile(filepath.Join(config.Load().GetWorkDir(), path))
+	if err != nil {
+		return cf, err
+	}
+	if isBinary(data) {
+		return cf, ErrBinaryConflict
+	}
+
+	text := string(data)
+	cf.crlf = strings.Contains(text, "\r\n")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	var plain []string
+	var cur *Conflict
+	state := "" // "", "ours", "base", "theirs"
+
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if line == "" {
+			continue
+		}
+		bare := strings.TrimSuffix(line, "\n")
+
+		switch {
+		case state == "" && conflictMarker(bare, "<<<<<<<"):
+			if len(plain) > 0 {
+				cf.segments = append(cf.segments, segment{text: plain})
+				plain = nil
+			}
+			cur = &Conflict{OursLabel: strings.TrimSpace(strings.TrimPrefix(bare, "<<<<<<<"))}
+			state = "ours"
+		case state == "ours" && conflictMarker(bare, "|||||||"):
+			state = "base"
+		case (state == "ours" || state == "base") && bare == "=======":
+			state = "theirs"
+		case state == "theirs" && conflictMarker(bare, ">>>>>>>"):
+			cur.TheirsLabel = strings.TrimSpace(strings.TrimPrefix(bare, ">>>>>>>"))
+			cf.Conflicts = append(cf.Conflicts, cur)
+			cf.segments = append(cf.segments, segment{conflict: cur})
+			cur, state = nil, ""
+		case state == "ours":
+			cur.Ours = append(cur.Ours, line)
+		case state == "base":
+			cur.Base = append(cur.Base, line)
+		case state == "theirs":
+			cur.Theirs = append(cur.Theirs, line)
+		default:
+			plain = append(plain, line)
+		}
+	}
+
+	if state != "" {
+		return cf, fmt.Errorf("%s: unterminated conflict marker", path)
+	}
+	if len(plain) > 0 {
+		cf.segments = append(cf.segments, segment{text: plain})
+	}
+	return cf, nil
+}
+
+// Unresolved counts conflicts without a pick
+func (cf ConflictFile) Unresolved() int {
+	n := 0
+	for _, c := range cf.Conflicts {
+		if c.Pick == PickNone {
+			n++
+		}
+	}
+	return n
+}
+
+// Content renders the file with every pick applied
+func (cf ConflictFile) Content() string {
+	var b strings.Builder
+	write := func(lines []string) {
+		for _, l := range lines {
+			b.WriteString(l)
+		}
+	}
+
+	for _, s := range cf.segments {
+		c := s.conflict
+		if c == nil {
+			write(s.text)
+			continue
+		}
+
+		switch c.Pick {
+		case PickOurs:
+			write(c.Ours)
+		case PickTheirs:
+			write(c.Theirs)
+		case PickBoth:
+			write(c.Ours)
+			write(c.Theirs)
+		default:
+			b.WriteString("<<<<<<< " + c.OursLabel + "\n")
+			write(c.Ours)
+			if len(c.Base) > 0 {
+				b.WriteString("|||||||\n")
+				write(c.Base)
+			}
+			b.WriteString("=======\n")
+			write(c.Theirs)
+			b.WriteString(">>>>>>> " + c.TheirsLabel + "\n")
+		}
+	}
+
+	out := b.String()
+	if cf.crlf {
+		out = strings.ReplaceAll(out, "\n", "\r\n")
+	}
+	return out
+}
+
+/*
+WriteResolution saves the picks; a file without markers left
+is marked resolved (git add)
+*/
+func WriteResolution(cf ConflictFile) (Result, error) {
+	res := newResult("resolve")
+	full := filepath.Join(config.Load().GetWorkDir(), cf.Path)
+
+	info, err := os.Stat(full)
+	if err != nil {
+		return res, err
+	}
+	if err := os.WriteFile(full, []byte(cf.Content()), info.Mode().Perm()); err != nil {
+		return res, err
+	}
+
+	res.addRef(cf.Path)
+	if left := cf.Unresolved(); left > 0 {
+		res.Summary = fmt.Sprintf("%s saved, %d conflict(s) left", cf.Path, left)
+		return res, nil
+	}
+	return MarkResolved(cf.Path)
+}
+
+// HasConflictMarkers reports leftover markers (after editing by hand)
+func HasConflictMarkers(path string) bool {
+	cf, err := ReadConflicts(path)
+	return err != nil || len(cf.Conflicts) > 0
+}
+
+// MarkResolved stages path (git add), or its deletion when it is gone
+func MarkResolved(path string) (Result, error) {
+	res := newResult("resolve")
+
+	full := filepath.Join(config.Load().GetWorkDir(), path)
+	args := []string{"add", "--", path}
+	if _, err := os.Stat(full); os.IsNotExist(err) {
+		args = []string{"rm", "-q", "--cached", "--", path}
+	}
+
+	if err := res.git(args...); err != nil {
+		return res, err
+	}
+
+	res.addRef(path)
+	res.Summary = path + " marked as resolved"
+	return res, nil
+}
+
+/*
+TakeSide resolves a whole file with one side (binary files,
+modify/delete conflicts). A side that deleted the file removes it.
+*/
+func TakeSide(path, side string) (Result, error) {
+	res := newResult("resolve")
+
+	if side != PickOurs && side != PickTheirs {
+		return res, ErrInvalidArguments
+	}
+
+	stage := ":2:"
+	if side == PickTheirs {
+		stage = ":3:"
+	}
+
+	if system.GitOK("cat-file", "-e", stage+path) {
+		if err := res.git("checkout", "--"+side, "--", path); err != nil {
+			return res, err
+		}
+		return MarkResolved(path)
+	}
+
+	// that side deleted the file
+	if err := res.git("rm", "-q", "--", path); err != nil {
+		return res, err
+	}
+	res.addRef(path)
+	res.Summary = path + " removed (" + side + " deleted it)"
+	return res, nil
+}
+
+// isBinary mirrors git's heuristic: NUL in the first 8000 bytes
+func isBinary(data []byte) bool {
+	n := min(len(data), 8000)
+	for _, c := range data[:n] {
+		if c == 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package gitops
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestReadConflicts(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		conflicts []Conflict
+		pick      string
+		resolved  string
+	}{
+		{
+			name:    "merge style",
+			content: "a\n<<<<<<< HEAD\nours\n=======\ntheirs\n>>>>>>> feature\nz\n",
+			conflicts: []Conflict{
+				{OursLabel: "HEAD", TheirsLabel: "feature", Ours: []string{"ours\n"}, Theirs: []string{"theirs\n"}},
+			},
+			pick:     PickTheirs,
+			resolved: "a\ntheirs\nz\n",
+		},
+		{
+			name:    "diff3 style with base",
+			content: "<<<<<<< HEAD\nours\n|||||||\nold\n=======\ntheirs\n>>>>>>> feature\n",
+			conflicts: []Conflict{
+				{OursLabel: "HEAD", TheirsLabel: "feature", Ours: []string{"ours\n"}, Base: []string{"old\n"}, Theirs: []string{"theirs\n"}},
+			},
+			pick:     PickBoth,
+			resolved: "ours\ntheirs\n",
+		},
+		{
+			name:    "crlf line endings are kept",
+			content: "a\r\n<<<<<<< HEAD\r\nours\r\n=======\r\ntheirs\r\n>>>>>>> dev\r\n",
+			conflicts: []Conflict{
+				{OursLabel: "HEAD", TheirsLabel: "dev", Ours: []string{"ours\n"}, Theirs: []string{"theirs\n"}},
+			},
+			pick:     PickOurs,
+			resolved: "a\r\nours\r\n",
+		},
+		{
+			name:     "no markers",
+			content:  "plain\n",
+			resolved: "plain\n",
+		},
+	}
+
+	dir := chdirTemp(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := os.WriteFile(dir+"/file.txt", []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			cf, err := ReadConflicts("file.txt")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []Conflict
+			for _, c := range cf.Conflicts {
+				got = append(got, *c)
+			}
+			if !reflect.DeepEqual(got, tt.conflicts) {
+				t.Errorf("conflicts\n got %+v\nwant %+v", got, tt.conflicts)
+			}
+			if cf.Content() != tt.content {
+				t.Errorf("Content() without picks = %q, want the file unchanged", cf.Content())
+			}
+
+			for _, c := range cf.Conflicts {
+				c.Pick = tt.pick
+			}
+			if cf.Unresolved() != 0 {
+				t.Errorf("Unresolved() = %d after picking", cf.Unresolved())
+			}
+			if got := cf.Content(); got != tt.resolved {
+				t.Errorf("Content() = %q, want %q", got, tt.resolved)
+			}
+		})
+	}
+}
+
+func TestReadConflictsErrors(t *testing.T) {
+	dir := chdirTemp(t)
+
+	_ = os.WriteFile(dir+"/open.txt", []byte("<<<<<<< HEAD\nours\n=======\n"), 0644)
+	if _, err := ReadConflicts("open.txt"); err == nil {
+		t.Error("unterminated conflict: want an error")
+	}
+
+	_ = os.WriteFile(dir+"/blob.bin", []byte("<<<<<<< HEAD\x00\x01"), 0644)
+	if _, err := ReadConflicts("blob.bin"); err != ErrBinaryConflict {
+		t.Errorf("binary file: err = %v, want ErrBinaryConflict", err)
+	}
+}
@@ -0,0 +1,234 @@
+package gitops
+
+import (
+	"fmt"
+	"strings"
+
+	"git-genius/internal/system"
+)
+
+/* ============================================================
+   DIFFS (work tree, staged, commit to commit)
+   ============================================================ */
+
+// Diff sources
+const (
+	DiffWorktree = "worktree" // not staged yet (index → work tree) + untracked files
+	DiffStaged   = "staged"   // staged (HEAD → index)
+	DiffHead     = "head"     // everything uncommitted (HEAD → work tree) + untracked files
+	DiffCommits  = "commits"  // From → To (To empty = work tree)
+	DiffStash    = "stash"    // stash commit From: tracked changes + untracked files
+)
+
+// DiffRequest selects what to compare; Paths limits the files
+type DiffRequest struct {
+	Source string   `json:"source"`
+	From   string   `json:"from,omitempty"`
+	To     string   `json:"to,omitempty"`
+	Paths  []string `json:"paths,omitempty"`
+}
+
+// Diff returns the changed files of req (Result.Data = []FileDiff)
+func Diff(req DiffRequest) (Result, error) {
+	res := newResult("diff")
+
+	if !system.IsGitRepo() {
+		return res, ErrNotRepo
+	}
+
+	files, err := ReadDiff(req)
+	if err != nil {
+		return res, err
+	}
+
+	res.Data = files
+	for _, f := range files {
+		res.addRef(f.Path)
+	}
+	res.Summary = fmt.Sprintf("%d file(s) changed", len(files))
+	return res, nil
+}
+
+// ReadDiff runs git diff for req and splits the output per file
+func ReadDiff(req DiffRequest) ([]FileDiff, error) {
+	args := []string{"diff", "--no-color", "--no-ext-diff", "-M"}
+
+	switch req.Source {
+	case DiffWorktree:
+	case DiffStaged:
+		args = append(args, "--cached")
+	case DiffHead:
+		args = append(args, headOrEmptyTree())
+	case DiffCommits:
+		if req.From == "" {
+			return nil, fmt.Errorf("%w: commit to compare from", ErrInvalidArguments)
+		}
+		args = append(args, req.From)
+		if req.To != "" {
+			args = append(args, req.To)
+		}
+	case DiffStash:
+		if req.From == "" {
+			return nil, fmt.Errorf("%w: stash to show", ErrInvalidArguments)
+		}
+		args = append(args, req.From+"^1", req.From)
+	default:
+		return nil, fmt.Errorf("%w: diff source %q", ErrInvalidArguments, req.Source)
+	}
+	args = append(append(args, "--"), req.Paths...)
+
+	out, err := system.GitRaw(args...)
+	if err != nil {
+		return nil, gitErr(err, out, args...)
+	}
+	files := SplitDiff(out)
+
+	switch req.Source {
+	case DiffWorktree, DiffHead:
+		files = append(files, untrackedDiffs(req.Paths)...)
+	case DiffStash:
+		files = append(files, stashUntrackedDiffs(req.From, req.Paths)...)
+	}
+	return files, nil
+}
+
+// headOrEmptyTree lets DiffHead work before the first commit
+func headOrEmptyTree() string {
+	if hasAnyCommit() {
+		return "HEAD"
+	}
+	return emptyTree()
+}
+
+// untrackedDiffs shows untracked files as new files
+func untrackedDiffs(paths []string) []FileDiff {
+	out, err := system.GitRaw(append([]string{"ls-files", "--others", "--exclude-standard", "--"}, paths...)...)
+	if err != nil {
+		return nil
+	}
+
+	var files []FileDiff
+	for _, p := range splitLines(out) {
+		// --no-index exits 1 when files differ, the patch is still on stdout
+		resp, _ := system.Git(system.Request{
+			Args: []string{"diff", "--no-index", "--no-color", "--no-ext-diff", "--", "/dev/null", p},
+		})
+		files = append(files, SplitDiff(resp.Stdout)...)
+	}
+	return files
+}
+
+// stashUntrackedDiffs reads the untracked files a stash saved (its third parent)
+func stashUntrackedDiffs(stash string, paths []string) []FileDiff {
+	if !system.GitOK("rev-parse", "--verify", "--quiet", stash+"^3") {
+		return nil
+	}
+	args := append([]string{"diff", "--no-color", "--no-ext-diff", emptyTree(), stash + "^3", "--"}, paths...)
+	out, err := system.GitRaw(args...)
+	if err != nil {
+		return nil
+	}
+	return SplitDiff(out)
+}
+
+/*
+SplitDiff splits multi-file `git diff` output into FileDiffs
+Path / OldPath / Status come from the extended header lines
+*/
+func SplitDiff(out string) []FileDiff {
+	var files []FileDiff
+	var chunk []string
+
+	flush := func() {
+		if len(chunk) > 0 {
+			files = append(files, parseFileChunk(chunk))
+		}
+		chunk = nil
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+		if strings.HasPrefix(line, "diff --git ") {
+			flush()
+		}
+		if len(chunk) > 0 || strings.HasPrefix(line, "diff --git ") {
+			chunk = append(chunk, line)
+		}
+	}
+	flush()
+	return files
+}
+
+func parseFileChunk(lines []string) FileDiff {
+	oldPath, newPath := diffGitPaths(lines[0])
+	status := "modified"
+
+	for _, l := range lines[1:] {
+		if strings.HasPrefix(l, "@@") {
+			break
+		}
+		switch {
+		case strings.HasPrefix(l, "new file mode"):
+			status = "added"
+		case strings.HasPrefix(l, "deleted file mode"):
+			status = "deleted"
+		case strings.HasPrefix(l, "rename from "):
+			oldPath, status = unquotePath(strings.TrimPrefix(l, "rename from ")), "renamed"
+		case strings.HasPrefix(l, "rename to "):
+			newPath = unquotePath(strings.TrimPrefix(l, "rename to "))
+		case strings.HasPrefix(l, "--- ") && l != "--- /dev/null":
+			oldPath = strings.TrimPrefix(unquotePath(strings.TrimPrefix(l, "--- ")), "a/")
+		case strings.HasPrefix(l, "+++ ") && l != "+++ /dev/null":
+			newPath = strings.TrimPrefix(unquotePath(strings.TrimPrefix(l, "+++ ")), "b/")
+		}
+	}
+
+	path := newPath
+	if status == "deleted" {
+		path = oldPath
+	}
+
+	fd := ParseFileDiff(path, strings.Join(lines, "\n"))
+	fd.Status = status
+	if status == "renamed" {
+		fd.OldPath = oldPath
+	}
+	return fd
+}
+
+// diffGitPaths reads "diff --git a/x b/y" (good enough for unquoted paths)
+func diffGitPaths(line string) (string, string) {
+	rest := strings.TrimPrefix(line, "diff --git ")
+	if a, b, ok := strings.Cut(rest, " b/"); ok {
+		return strings.TrimPrefix(unquotePath(a), "a/"), b
+	}
+	return rest, rest
+}
+
+// unquotePath strips git's C-style quotes around unusual paths
+func unquotePath(p string) string {
+	if len(p) >= 2 && p[0] == '"' && p[len(p)-1] == '"' {
+		return strings.ReplaceAll(p[1:len(p)-1], `\"`, `"`)
+	}
+	return p
+}
+
+// Stats counts added and deleted lines
+func (fd FileDiff) Stats() (added, deleted int) {
+	for _, h := range fd.Hunks {
+		for _, l := range h.Lines {
+			switch {
+			case strings.HasPrefix(l, "+"):
+				added++
+			case strings.HasPrefix(l, "-"):
+				deleted++
+			}
+		}
+	}
+	return added, deleted
+}
+
+// CommitChanges is the DiffRequest showing what commit c changed
+func CommitChanges(c CommitSummary) DiffRequest {
+	r := commitRange(c)
+	return DiffRequest{Source: DiffCommits, From: r[0], To: r[1]}
+}
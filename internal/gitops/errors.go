@@ -0,0 +1,155 @@
+package gitops
+
+import (
+	"errors"
+	"strings"
+)
+
+/* ============================================================
+   OPERATION ERRORS
+   ============================================================ */
+
+var (
+	ErrNotRepo           = errors.New("not a git repository")
+	ErrNothingToCommit   = errors.New("nothing to commit")
+	ErrEmptyMessage      = errors.New("commit message cannot be empty")
+	ErrNoRemote          = errors.New("no remote configured")
+	ErrNoCommits         = errors.New("no commits found")
+	ErrCancelled         = errors.New("operation cancelled")
+	ErrInvalidArguments  = errors.New("invalid arguments")
+	ErrDirtyWorkTree     = errors.New("uncommitted changes present")
+	ErrNonFastForward    = errors.New("remote contains commits not present locally")
+	ErrConflict          = errors.New("conflicts must be resolved")
+	ErrAuth              = errors.New("authentication failed")
+	ErrNoStash           = errors.New("no stash entries found")
+	ErrInvalidMessage    = errors.New("commit message does not follow the rules")
+	ErrSecretsDetected   = errors.New("possible secrets detected")
+	ErrLargeFiles        = errors.New("files exceed the size limit")
+	ErrLFSMissing        = errors.New("git lfs is not installed")
+	ErrNothingToUndo     = errors.New("no git-genius operation to undo")
+	ErrStateChanged      = errors.New("repository changed since the operation")
+	ErrInvalidBranchName = errors.New("invalid branch name")
+	ErrNotMerged         = errors.New("branch is not fully merged")
+	ErrProtectedBranch   = errors.New("branch cannot be deleted")
+	ErrBinaryConflict    = errors.New("conflict in a binary file")
+	ErrOperationPending  = errors.New("another git operation is in progress")
+	ErrNothingPending    = errors.New("no operation in progress")
+	ErrRewriteAborted    = errors.New("commit rewrite aborted")
+	ErrInvalidTagName    = errors.New("invalid tag name")
+	ErrTagExists         = errors.New("tag already exists")
+)
+
+/*
+GitError wraps a failed git invocation
+Cause is set when the failure was recognised (non-fast-forward, conflict…)
+so errors.Is(err, ErrNonFastForward) works on the wrapped error
+*/
+type GitError struct {
+	Args   []string
+	Output string
+	Cause  error
+	Err    error
+}
+
+func (e *GitError) Error() string {
+	msg := "git " + strings.Join(e.Args, " ") + ": " + e.Err.Error()
+	if e.Cause != nil {
+		msg += " (" + e.Cause.Error() + ")"
+	}
+	return msg
+}
+
+func (e *GitError) Unwrap() []error {
+	if e.Cause != nil {
+		return []error{e.Cause, e.Err}
+	}
+	return []error{e.Err}
+}
+
+// knownFailures maps git output fragments to typed errors
+var knownFailures = []struct {
+	fragment string
+	err      error
+}{
+	{"non-fast-forward", ErrNonFastForward},
+	{"[rejected]", ErrNonFastForward},
+	{"fetch first", ErrNonFastForward},
+	{"CONFLICT", ErrConflict},
+	{"Automatic merge failed", ErrConflict},
+	{"needs merge", ErrConflict},
+	{"Authentication failed", ErrAuth},
+	{"could not read Username", ErrAuth},
+	{"Permission denied (publickey)", ErrAuth},
+	{"No stash entries found", ErrNoStash},
+	{"would be overwritten by checkout", ErrDirtyWorkTree},
+	{"nothing to commit", ErrNothingToCommit},
+}
+
+func gitErr(err error, output string, args ...string) error {
+	if err == nil {
+		return nil
+	}
+
+	e := &GitError{Args: args, Output: output, Err: err}
+	for _, k := range knownFailures {
+		if strings.Contains(output, k.fragment) {
+			e.Cause = k.err
+			break
+		}
+	}
+	return e
+}
+
+/*
+ErrorKind returns a stable, machine-readable name for err
+Used by JSON output and wrappers; empty string for nil
+*/
+func ErrorKind(err error) string {
+	var gitFailure *GitError
+
+	kinds := []struct {
+		err  error
+		kind string
+	}{
+		{ErrNotRepo, "not_repo"},
+		{ErrNothingToCommit, "nothing_to_commit"},
+		{ErrEmptyMessage, "empty_message"},
+		{ErrNoRemote, "no_remote"},
+		{ErrNoCommits, "no_commits"},
+		{ErrCancelled, "cancelled"},
+		{ErrInvalidArguments, "invalid_arguments"},
+		{ErrDirtyWorkTree, "dirty_work_tree"},
+		{ErrNonFastForward, "non_fast_forward"},
+		{ErrConflict, "conflict"},
+		{ErrAuth, "auth_failed"},
+		{ErrNoStash, "no_stash"},
+		{ErrInvalidMessage, "invalid_message"},
+		{ErrSecretsDetected, "secrets_detected"},
+		{ErrLargeFiles, "large_files"},
+		{ErrLFSMissing, "lfs_missing"},
+		{ErrNothingToUndo, "nothing_to_undo"},
+		{ErrStateChanged, "state_changed"},
+		{ErrInvalidBranchName, "invalid_branch_name"},
+		{ErrNotMerged, "not_merged"},
+		{ErrProtectedBranch, "protected_branch"},
+		{ErrBinaryConflict, "binary_conflict"},
+		{ErrOperationPending, "operation_pending"},
+		{ErrNothingPending, "nothing_pending"},
+		{ErrRewriteAborted, "rewrite_aborted"},
+		{ErrInvalidTagName, "invalid_tag_name"},
+		{ErrTagExists, "tag_exists"},
+	}
+
+	if err == nil {
+		return ""
+	}
+	for _, k := range kinds {
+		if errors.Is(err, k.err) {
+			return k.kind
+		}
+	}
+	if errors.As(err, &gitFailure) {
+		return "git_failed"
+	}
+	return "unknown"
+}
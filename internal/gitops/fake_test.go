@@ -0,0 +1,64 @@
+package gitops
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"git-genius/internal/system"
+)
+
+// useFake makes every git call of the test answer from a FakeRunner
+func useFake(t *testing.T) *system.FakeRunner {
+	t.Helper()
+
+	fake := system.NewFakeRunner()
+	prev := system.SetRunner(fake)
+	t.Cleanup(func() { system.SetRunner(prev) })
+	return fake
+}
+
+// chdirTemp runs the test in an empty directory (config and work dir)
+func chdirTemp(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+	return dir
+}
+
+/*
+tempRepo runs the test in a new repository using the real git binary
+git runs a command there and fails the test when it exits non-zero
+*/
+func tempRepo(t *testing.T) (dir string, git func(args ...string) string) {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir = chdirTemp(t)
+
+	git = func(args ...string) string {
+		t.Helper()
+		out, err := exec.Command("git", args...).CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+		}
+		return string(out)
+	}
+
+	git("init", "-q")
+	git("config", "user.name", "Test")
+	git("config", "user.email", "test@example.com")
+	git("config", "commit.gpgsign", "false")
+	return dir, git
+}
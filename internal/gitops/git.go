@@ -1,12 +1,12 @@
 package gitops
 
 import (
-	"bytes"
+	"fmt"
 	"strings"
 
+	"git-genius/internal/commitmsg"
 	"git-genius/internal/config"
 	"git-genius/internal/system"
-	"git-genius/internal/ui"
 )
 
 /* ============================================================
@@ -16,17 +16,13 @@ import (
 // hasAnyCommit checks whether repo has at least one commit
 // Uses git log (rev-parse is unsafe on some Android kernels)
 func hasAnyCommit() bool {
-	cmd := system.GitCmd("log", "-1")
-	return cmd.Run() == nil
+	return system.GitOK("log", "-1")
 }
 
 // isWorkingTreeDirty checks for uncommitted changes
 func isWorkingTreeDirty() bool {
-	var out bytes.Buffer
-	cmd := system.GitCmd("status", "--porcelain")
-	cmd.Stdout = &out
-	_ = cmd.Run()
-	return out.Len() > 0
+	out, _ := system.GitRaw("status", "--porcelain")
+	return len(out) > 0
 }
 
 // ensureSafeDirectory fixes Git ≥2.35 "dubious ownership" (Android fix)
@@ -35,7 +31,7 @@ func ensureSafeDirectory() {
 	if cfg.WorkDir == "" {
 		return
 	}
-	_ = system.RunGit("config", "--global", "--add", "safe.directory", cfg.WorkDir)
+	_, _ = system.GitCombined("config", "--global", "--add", "safe.directory", cfg.WorkDir)
 }
 
 /* ============================================================
@@ -43,15 +39,12 @@ func ensureSafeDirectory() {
    ============================================================ */
 
 func CurrentBranch() string {
-	var out bytes.Buffer
-	cmd := system.GitCmd("branch", "--show-current")
-	cmd.Stdout = &out
-
-	if err := cmd.Run(); err != nil {
+	out, err := system.GitRaw("branch", "--show-current")
+	if err != nil {
 		return "-"
 	}
 
-	b := strings.TrimSpace(out.String())
+	b := strings.TrimSpace(out)
 	if b == "" {
 		return "-"
 	}
@@ -64,81 +57,168 @@ func CurrentRemote() string {
 		return "-"
 	}
 
-	cmd := system.GitCmd("remote", "get-url", cfg.Remote)
-	if err := cmd.Run(); err != nil {
+	if !system.GitOK("remote", "get-url", cfg.Remote) {
 		return "-"
 	}
 
 	return cfg.Remote
 }
 
+// IsDirty reports uncommitted changes (frontends ask before SmartPull)
+func IsDirty() bool {
+	return isWorkingTreeDirty()
+}
+
+// HasCommits reports whether the repository has at least one commit
+func HasCommits() bool {
+	return hasAnyCommit()
+}
+
 /* ============================================================
    CORE GIT OPERATIONS
    ============================================================ */
 
-func Status() {
-	if !system.EnsureGitRepo() {
-		return
-	}
+// Status returns the parsed repository status (see RepoStatus)
+func Status() (Result, error) {
+	res := newResult("status")
 
-	if err := system.RunGit("status"); err != nil {
-		ui.Error("Failed to get git status")
+	st, err := ReadStatus()
+	if err != nil {
+		return res, err
 	}
+
+	res.addRef(st.Branch, st.Upstream)
+	res.Data = st
+	return res, nil
 }
 
-func Push(msg string) {
-	if !system.EnsureGitRepo() {
-		return
+// PushOptions controls what Push commits
+type PushOptions struct {
+	Message string
+	// Paths = files to commit; nil stages everything (git add .)
+	// A non-nil empty slice means "nothing selected"
+	Paths []string
+	// KeepIndex commits the index exactly as staged (hunk staging)
+	KeepIndex bool
+	// AllowSecrets skips the secret scan (explicit user override)
+	AllowSecrets bool
+	// AllowLargeFiles commits files above config.LargeFileMB
+	AllowLargeFiles bool
+	// PushOnly leaves the work tree alone and pushes existing commits
+	PushOnly bool
+}
+
+/*
+Push stages the selection, commits with opts.Message and pushes the current branch
+First commit: empty message defaults to "Initial commit"
+Clean tree with unpushed commits: push only
+Staged changes and outgoing commits are scanned for secrets
+(ErrSecretsDetected, findings in Result.Data) unless opts.AllowSecrets
+Staged files over the size limit stop the commit
+(ErrLargeFiles, []LargeFile in Result.Data) unless opts.AllowLargeFiles
+*/
+func Push(opts PushOptions) (Result, error) {
+	return journaled("push", func() (Result, error) { return push(opts) })
+}
+
+func push(opts PushOptions) (Result, error) {
+	res := newResult("push")
+	msg := opts.Message
+
+	if !system.IsGitRepo() {
+		return res, ErrNotRepo
 	}
 
 	// 🔐 Android / Git ≥2.35 safety
 	ensureSafeDirectory()
 
+	if err := checkNoPending(); err != nil {
+		return res, err
+	}
+
 	cfg := config.Load()
 
 	// ---------- NO CHANGES ----------
-	if hasAnyCommit() && !isWorkingTreeDirty() {
-		ui.Warn("Nothing to commit")
-		return
+	pushOnly := opts.PushOnly || (hasAnyCommit() && !isWorkingTreeDirty())
+	if pushOnly && (!hasAnyCommit() || cfg.Remote == "" || !hasOutgoingCommits(cfg.Remote)) {
+		return res, ErrNothingToCommit
+	}
+
+	if opts.Paths != nil && len(opts.Paths) == 0 && !opts.KeepIndex {
+		return res, ErrNothingToCommit
+	}
+
+	// ---------- MESSAGE RULES (config.CommitRules) ----------
+	if msg != "" {
+		if problems := commitmsg.Validate(msg, cfg.CommitRules); len(problems) > 0 {
+			if cfg.CommitRules.Enforcement == config.EnforceBlock {
+				return res, fmt.Errorf("%w: %s", ErrInvalidMessage, strings.Join(problems, "; "))
+			}
+			for _, p := range problems {
+				res.warn("Commit message: " + p)
+			}
+		}
 	}
 
 	// ---------- FIRST COMMIT ----------
-	if !hasAnyCommit() {
+	if pushOnly {
+		res.Summary = "No local changes, pushing existing commits"
+	} else if !hasAnyCommit() {
 		if msg == "" {
 			msg = "Initial commit"
 		}
 
-		ui.Info("Creating first commit")
-		_ = system.RunGit("add", ".")
+		if err := res.stageFor(opts, false); err != nil {
+			return res, err
+		}
+
+		if err := res.checkLargeFiles(opts); err != nil {
+			return res, err
+		}
 
-		if err := system.RunGit("commit", "-m", msg); err != nil {
-			ui.Error("Initial commit failed")
-			return
+		if err := res.scanStaged(opts); err != nil {
+			return res, err
 		}
 
-		ui.Success("Initial commit created")
+		if err := res.git("commit", "-m", msg); err != nil {
+			return res, err
+		}
+
+		res.Summary = "Initial commit created"
+		res.addRef(CurrentBranch())
 
 		if cfg.Remote == "" {
-			ui.Warn("No remote configured")
-			ui.Info("Run: Tools → Create / Link GitHub Repository")
-			return
+			res.warn("No remote configured")
+			return res, nil
+		}
+	} else {
+		// ---------- NORMAL COMMIT ----------
+		if msg == "" {
+			return res, ErrEmptyMessage
 		}
-	}
 
-	// ---------- NORMAL COMMIT ----------
-	if msg == "" {
-		ui.Error("Commit message cannot be empty")
-		return
-	}
+		if err := res.stageFor(opts, true); err != nil {
+			return res, err
+		}
 
-	_ = system.RunGit("add", ".")
-	_ = system.RunGit("commit", "-m", msg) // ignore "nothing to commit"
+		if err := res.checkLargeFiles(opts); err != nil {
+			return res, err
+		}
+
+		if err := res.scanStaged(opts); err != nil {
+			return res, err
+		}
+
+		if opts.Paths == nil && !opts.KeepIndex {
+			_ = res.git("commit", "-m", msg) // ignore "nothing to commit"
+		} else if err := res.git("commit", "-m", msg); err != nil {
+			return res, err
+		}
+	}
 
 	// ---------- PUSH ----------
 	if cfg.Remote == "" {
-		ui.Warn("No remote configured")
-		ui.Info("Run: Tools → Create / Link GitHub Repository")
-		return
+		return res, ErrNoRemote
 	}
 
 	branch := CurrentBranch()
@@ -146,17 +226,119 @@ func Push(msg string) {
 		branch = cfg.Branch
 	}
 
-	if err := system.RunGit("push", "-u", cfg.Remote, branch); err != nil {
-		ui.Error("Push failed")
-		return
+	res.Refs = nil
+	res.addRef(branch, cfg.Remote+"/"+branch)
+
+	if !opts.AllowSecrets {
+		findings, err := ScanOutgoing(cfg.Remote)
+		if err != nil {
+			return res, err
+		}
+		if len(findings) > 0 {
+			if !pushOnly {
+				res.warn("Commit created locally, push stopped")
+			}
+			return res, res.secretsFound(findings)
+		}
 	}
 
-	ui.Success("Changes pushed successfully")
+	if err := res.git("push", "-u", cfg.Remote, branch); err != nil {
+		return res, err
+	}
+
+	res.Summary = "Changes pushed successfully"
+	return res, nil
 }
 
-func Pull() {
-	if !system.EnsureGitRepo() {
-		return
+// scanStaged blocks the commit when the index contains secrets
+func (r *Result) scanStaged(opts PushOptions) error {
+	if opts.AllowSecrets {
+		return nil
+	}
+
+	findings, err := ScanStaged()
+	if err != nil {
+		return err
+	}
+	if len(findings) > 0 {
+		return r.secretsFound(findings)
+	}
+	return nil
+}
+
+// stageFor prepares the index for a commit according to opts
+func (r *Result) stageFor(opts PushOptions, hasCommits bool) error {
+	if !opts.KeepIndex {
+		return r.stage(opts.Paths, hasCommits)
+	}
+
+	if hasCommits && system.GitOK("diff", "--cached", "--quiet") {
+		return ErrNothingToCommit
+	}
+	return nil
+}
+
+/*
+stage makes the index contain exactly the selected paths
+nil paths = everything (git add .)
+Only paths staged earlier but not selected are unstaged (work tree untouched)
+*/
+func (r *Result) stage(paths []string, hasCommits bool) error {
+	if paths == nil {
+		return r.git("add", ".")
+	}
+
+	if unpicked := stagedExcept(paths); len(unpicked) > 0 {
+		args := append([]string{"reset", "-q", "--"}, unpicked...)
+		if !hasCommits {
+			args = append([]string{"rm", "--cached", "-q", "--"}, unpicked...)
+		}
+		if err := r.git(args...); err != nil {
+			return err
+		}
+	}
+
+	args := append([]string{"add", "-A", "--"}, paths...)
+	if err := r.git(args...); err != nil {
+		return err
+	}
+
+	if system.GitOK("diff", "--cached", "--quiet") && hasCommits {
+		return ErrNothingToCommit
+	}
+	return nil
+}
+
+// stagedExcept lists staged paths (both sides of renames) missing from paths
+func stagedExcept(paths []string) []string {
+	picked := map[string]bool{}
+	for _, p := range paths {
+		picked[p] = true
+	}
+
+	out, _ := system.GitRaw("diff", "--cached", "--name-only", "--no-renames", "-z")
+	var unpicked []string
+	for _, p := range strings.Split(out, "\x00") {
+		if p != "" && !picked[p] {
+			unpicked = append(unpicked, p)
+		}
+	}
+	return unpicked
+}
+
+func Pull() (Result, error) {
+	return journaled("pull", pull)
+}
+
+func pull() (Result, error) {
+	res := newResult("pull")
+
+	if !system.IsGitRepo() {
+		return res, ErrNotRepo
+	}
+
+	if err := checkNoPending(); err != nil {
+		return res, err
 	}
 
 	cfg := config.Load()
@@ -165,23 +347,31 @@ func Pull() {
 		branch = cfg.Branch
 	}
 
-	if err := system.RunGit("pull", cfg.Remote, branch); err != nil {
-		ui.Error("Pull failed")
-		return
+	res.addRef(branch, cfg.Remote+"/"+branch)
+
+	if err := res.git("pull", cfg.Remote, branch); err != nil {
+		return res, err
 	}
 
-	ui.Success("Pull completed")
+	res.Summary = "Pull completed"
+	return res, nil
 }
 
-func Fetch() {
-	if !system.EnsureGitRepo() {
-		return
+func Fetch() (Result, error) {
+	res := newResult("fetch")
+
+	if !system.IsGitRepo() {
+		return res, ErrNotRepo
 	}
 
-	if err := system.RunGit("fetch", "--all"); err != nil {
-		ui.Error("Fetch failed")
-		return
+	if err := res.git("fetch", "--all"); err != nil {
+		return res, err
+	}
+
+	if remotes, err := system.GitOutput("remote"); err == nil && remotes != "" {
+		res.addRef(strings.Split(remotes, "\n")...)
 	}
 
-	ui.Success("Fetched all remotes")
+	res.Summary = "Fetched all remotes"
+	return res, nil
 }
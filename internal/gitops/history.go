@@ -0,0 +1,258 @@
+package gitops
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"git-genius/internal/system"
+)
+
+/* ============================================================
+   HISTORY (git log --graph with filters, one page at a time)
+   ============================================================ */
+
+// LogFilter narrows the history; empty fields are ignored
+type LogFilter struct {
+	Author string `json:"author,omitempty"`
+	Path   string `json:"path,omitempty"`
+	Since  string `json:"since,omitempty"` // anything git understands: 2024-01-31, "2 weeks ago"
+	Until  string `json:"until,omitempty"`
+	Grep   string `json:"grep,omitempty"` // message text, case-insensitive
+	All    bool   `json:"all,omitempty"`  // every branch instead of HEAD
+}
+
+// Active reports whether any filter is set
+func (f LogFilter) Active() bool {
+	return f.Author != "" || f.Path != "" || f.Since != "" || f.Until != "" || f.Grep != ""
+}
+
+// CommitSummary is one commit of the log
+type CommitSummary struct {
+	Hash    string    `json:"hash"`
+	Parents []string  `json:"parents"`
+	Author  string    `json:"author"`
+	Email   string    `json:"email"`
+	When    time.Time `json:"when"`
+	Refs    string    `json:"refs,omitempty"` // HEAD -> main, origin/main, tag: v1.0
+	Subject string    `json:"subject"`
+}
+
+// LogLine is one graph row; Commit is nil on connector-only rows
+type LogLine struct {
+	Graph  string         `json:"graph"`
+	Commit *CommitSummary `json:"commit,omitempty"`
+}
+
+// LogPage is one page of history
+type LogPage struct {
+	Lines []LogLine `json:"lines"`
+	More  bool      `json:"more"` // older commits exist
+}
+
+// Commits returns the commits of the page (graph rows skipped)
+func (p LogPage) Commits() []CommitSummary {
+	var list []CommitSummary
+	for _, l := range p.Lines {
+		if l.Commit != nil {
+			list = append(list, *l.Commit)
+		}
+	}
+	return list
+}
+
+// FileStat is one changed file of a commit
+type FileStat struct {
+	Path    string `json:"path"`
+	Added   int    `json:"added"`
+	Deleted int    `json:"deleted"`
+	Binary  bool   `json:"binary"`
+}
+
+// CommitInfo is the detail view of one commit
+type CommitInfo struct {
+	CommitSummary
+	Body      string     `json:"body,omitempty"`
+	Committer string     `json:"committer"`
+	Committed time.Time  `json:"committed"`
+	Files     []FileStat `json:"files"`
+}
+
+// logMarker starts the commit part of a --graph row
+const logMarker = "\x1e"
+
+// Log returns one page of history (Result.Data = LogPage)
+func Log(filter LogFilter, offset, limit int) (Result, error) {
+	res := newResult("log")
+
+	if !system.IsGitRepo() {
+		return res, ErrNotRepo
+	}
+	if !hasAnyCommit() {
+		return res, ErrNoCommits
+	}
+
+	page, err := ReadLog(filter, offset, limit)
+	if err != nil {
+		return res, err
+	}
+
+	res.Data = page
+	res.Summary = fmt.Sprintf("%d commit(s)", len(page.Commits()))
+	return res, nil
+}
+
+/*
+ReadLog reads commits offset..offset+limit of the filtered history
+The graph is drawn from the newest commit, so earlier rows are read
+and skipped (--skip would break the graph lines)
+*/
+func ReadLog(filter LogFilter, offset, limit int) (LogPage, error) {
+	var page LogPage
+
+	args := []string{"log", "--graph", "--no-color", "--date-order",
+		"--format=" + logMarker + "%H%x1f%P%x1f%an%x1f%ae%x1f%ct%x1f%D%x1f%s",
+		"-n", strconv.Itoa(offset + limit + 1)}
+	args = append(args, filter.args()...)
+
+	out, err := system.GitRaw(args...)
+	if err != nil {
+		return page, gitErr(err, out, args...)
+	}
+
+	index := -1
+	for _, row := range strings.Split(strings.TrimRight(out, "\n"), "\n") {
+		graph, rest, isCommit := strings.Cut(row, logMarker)
+		if isCommit {
+			index++
+		}
+		if index < offset {
+			continue
+		}
+		if index >= offset+limit {
+			page.More = true
+			break
+		}
+
+		line := LogLine{Graph: strings.TrimRight(graph, " ")}
+		if isCommit {
+			c, ok := parseCommitSummary(rest)
+			if !ok {
+				continue
+			}
+			line.Graph = graph
+			line.Commit = &c
+		}
+		page.Lines = append(page.Lines, line)
+	}
+	return page, nil
+}
+
+func (f LogFilter) args() []string {
+	var args []string
+	if f.Author != "" {
+		args = append(args, "--author="+f.Author)
+	}
+	if f.Grep != "" {
+		args = append(args, "--grep="+f.Grep, "--regexp-ignore-case", "--fixed-strings")
+	}
+	if f.Since != "" {
+		args = append(args, "--since="+f.Since)
+	}
+	if f.Until != "" {
+		args = append(args, "--until="+f.Until)
+	}
+	if f.All {
+		args = append(args, "--all")
+	}
+	if f.Path != "" {
+		args = append(args, "--", f.Path)
+	}
+	return args
+}
+
+func parseCommitSummary(s string) (CommitSummary, bool) {
+	f := strings.SplitN(s, fieldSep, 7)
+	if len(f) != 7 {
+		return CommitSummary{}, false
+	}
+	return CommitSummary{
+		Hash:    f[0],
+		Parents: strings.Fields(f[1]),
+		Author:  f[2],
+		Email:   f[3],
+		When:    unixTime(f[4]),
+		Refs:    f[5],
+		Subject: f[6],
+	}, true
+}
+
+// CommitDetail reads message, committer and file stats of rev
+func CommitDetail(rev string) (CommitInfo, error) {
+	var info CommitInfo
+
+	args := []string{"show", "-s", "--format=%H%x1f%P%x1f%an%x1f%ae%x1f%at%x1f%D%x1f%s%x1f%cn%x1f%ct%x1f%b", rev, "--"}
+	out, err := system.GitRaw(args...)
+	if err != nil {
+		return info, gitErr(err, out, args...)
+	}
+
+	f := strings.SplitN(out, fieldSep, 10)
+	if len(f) != 10 {
+		return info, fmt.Errorf("%w: unexpected git show output", ErrInvalidArguments)
+	}
+
+	summary, _ := parseCommitSummary(strings.Join(f[:7], fieldSep))
+	info = CommitInfo{
+		CommitSummary: summary,
+		Committer:     f[7],
+		Committed:     unixTime(f[8]),
+		Body:          strings.TrimSpace(f[9]),
+	}
+
+	stats, err := system.GitRaw(append([]string{"diff", "--numstat", "-M"}, commitRange(info.CommitSummary)...)...)
+	if err != nil {
+		return info, err
+	}
+	info.Files = parseNumstat(stats)
+	return info, nil
+}
+
+// commitRange compares a commit with its first parent (empty tree for root commits)
+// Merges are shown against their first parent
+func commitRange(c CommitSummary) []string {
+	if len(c.Parents) == 0 {
+		return []string{emptyTree(), c.Hash}
+	}
+	return []string{c.Parents[0], c.Hash}
+}
+
+// emptyTree is the id of the empty tree (sha1 or sha256 repos)
+func emptyTree() string {
+	resp, err := system.Git(system.Request{
+		Args:  []string{"hash-object", "-t", "tree", "--stdin"},
+		Stdin: strings.NewReader(""),
+	})
+	if err != nil {
+		return "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+	}
+	return strings.TrimSpace(resp.Stdout)
+}
+
+// parseNumstat parses `git diff --numstat` ("-" counts = binary)
+func parseNumstat(out string) []FileStat {
+	stats := []FileStat{}
+
+	for _, l := range splitLines(out) {
+		f := strings.SplitN(l, "\t", 3)
+		if len(f) != 3 {
+			continue
+		}
+		s := FileStat{Path: f[2], Binary: f[0] == "-"}
+		s.Added, _ = strconv.Atoi(f[0])
+		s.Deleted, _ = strconv.Atoi(f[1])
+		stats = append(stats, s)
+	}
+	return stats
+}
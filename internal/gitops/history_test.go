@@ -0,0 +1,123 @@
+package gitops
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"git-genius/internal/system"
+)
+
+// graphLog is `git log --graph` output of a merge of topic into main
+var graphLog = strings.Join([]string{
+	"* " + logMarker + "a1\x1fb2 c3\x1fAda\x1fada@example.com\x1f1700000300\x1fHEAD -> main\x1fMerge branch 'topic'",
+	"|\\  ",
+	"| * " + logMarker + "c3\x1fb0\x1fBob\x1fbob@example.com\x1f1700000200\x1f\x1ftopic work",
+	"* | " + logMarker + "b2\x1fb0\x1fAda\x1fada@example.com\x1f1700000100\x1f\x1fmain work",
+	"|/  ",
+	"* " + logMarker + "b0\x1f\x1fAda\x1fada@example.com\x1f1700000000\x1ftag: v1.0\x1finit",
+}, "\n") + "\n"
+
+func TestReadLogPages(t *testing.T) {
+	type row struct {
+		graph string
+		hash  string // "" = connector row
+	}
+
+	tests := []struct {
+		name   string
+		offset int
+		limit  int
+		want   []row
+		more   bool
+	}{
+		{"first page", 0, 2, []row{{"* ", "a1"}, {"|\\", ""}, {"| * ", "c3"}}, true},
+		{"second page", 2, 2, []row{{"* | ", "b2"}, {"|/", ""}, {"* ", "b0"}}, false},
+		{"past the end", 4, 2, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFake(t)
+			fake.On("log --graph", system.Response{Stdout: graphLog})
+
+			page, err := ReadLog(LogFilter{}, tt.offset, tt.limit)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []row
+			for _, l := range page.Lines {
+				r := row{graph: l.Graph}
+				if l.Commit != nil {
+					r.hash = l.Commit.Hash
+				}
+				got = append(got, r)
+			}
+			if !reflect.DeepEqual(got, tt.want) || page.More != tt.more {
+				t.Errorf("ReadLog(%d, %d) = %v more=%v, want %v more=%v", tt.offset, tt.limit, got, page.More, tt.want, tt.more)
+			}
+
+			// one commit more than the page to know whether older ones exist
+			n := tt.offset + tt.limit + 1
+			if cmds := fake.Commands(); len(cmds) != 1 || !strings.HasSuffix(cmds[0], " -n "+strconv.Itoa(n)) {
+				t.Errorf("commands = %q, want a log ending in -n %d", cmds, n)
+			}
+		})
+	}
+}
+
+func TestReadLogParsesCommits(t *testing.T) {
+	fake := useFake(t)
+	fake.On("log --graph", system.Response{Stdout: graphLog})
+
+	page, err := ReadLog(LogFilter{}, 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commits := page.Commits()
+	if len(commits) != 4 {
+		t.Fatalf("Commits() = %d commits, want 4", len(commits))
+	}
+
+	merge := commits[0]
+	if !reflect.DeepEqual(merge.Parents, []string{"b2", "c3"}) || merge.Refs != "HEAD -> main" ||
+		merge.Author != "Ada" || merge.Email != "ada@example.com" || merge.When.Unix() != 1700000300 ||
+		merge.Subject != "Merge branch 'topic'" {
+		t.Errorf("merge commit = %+v", merge)
+	}
+	if root := commits[3]; len(root.Parents) != 0 || root.Refs != "tag: v1.0" {
+		t.Errorf("root commit = %+v", root)
+	}
+}
+
+func TestLogFilterArgs(t *testing.T) {
+	f := LogFilter{Author: "ada", Grep: "fix", Since: "2 weeks ago", All: true, Path: "main.go"}
+	want := []string{
+		"--author=ada",
+		"--grep=fix", "--regexp-ignore-case", "--fixed-strings",
+		"--since=2 weeks ago",
+		"--all",
+		"--", "main.go",
+	}
+	if got := f.args(); !reflect.DeepEqual(got, want) {
+		t.Errorf("args() = %q, want %q", got, want)
+	}
+	if (LogFilter{All: true}).Active() {
+		t.Error("All alone counts as an active filter")
+	}
+}
+
+func TestParseNumstat(t *testing.T) {
+	got := parseNumstat("3\t1\tmain.go\n-\t-\tlogo.png\n10\t0\tdocs/new file.md\n")
+	want := []FileStat{
+		{Path: "main.go", Added: 3, Deleted: 1},
+		{Path: "logo.png", Binary: true},
+		{Path: "docs/new file.md", Added: 10},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseNumstat() = %+v, want %+v", got, want)
+	}
+}
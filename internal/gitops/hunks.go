@@ -0,0 +1,323 @@
+package gitops
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"git-genius/internal/system"
+)
+
+/* ============================================================
+   HUNK STAGING (git add -p without the raw interface)
+   ============================================================ */
+
+// Hunk is one @@ block of a unified diff
+type Hunk struct {
+	OldStart int      `json:"old_start"`
+	OldLines int      `json:"old_lines"`
+	NewStart int      `json:"new_start"`
+	NewLines int      `json:"new_lines"`
+	Section  string   `json:"section,omitempty"` // text after the second @@
+	Lines    []string `json:"lines"`             // " ctx", "-old", "+new", "\ No newline…"
+}
+
+// Header renders the @@ line
+func (h Hunk) Header() string {
+	hdr := fmt.Sprintf("@@ -%d,%d +%d,%d @@", h.OldStart, h.OldLines, h.NewStart, h.NewLines)
+	if h.Section != "" {
+		hdr += " " + h.Section
+	}
+	return hdr
+}
+
+// FileDiff is the diff of one file split into hunks
+type FileDiff struct {
+	Path    string   `json:"path"`
+	OldPath string   `json:"old_path,omitempty"` // renames
+	Status  string   `json:"status,omitempty"`   // added, deleted, renamed, modified
+	Header  []string `json:"header"`             // diff --git / index / --- / +++ lines
+	Hunks   []Hunk   `json:"hunks"`
+	Binary  bool     `json:"binary"`
+}
+
+/*
+UnstagedHunks returns the work tree changes of path that are not staged yet
+*/
+func UnstagedHunks(path string) (FileDiff, error) {
+	args := []string{"diff", "--no-color", "--no-ext-diff", "--", path}
+	out, err := system.GitRaw(args...)
+	if err != nil {
+		return FileDiff{Path: path}, gitErr(err, out, args...)
+	}
+	return ParseFileDiff(path, out), nil
+}
+
+// ParseFileDiff parses single-file unified diff output
+func ParseFileDiff(path, out string) FileDiff {
+	fd := FileDiff{Path: path}
+	var cur *Hunk
+
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			h, ok := parseHunkHeader(line)
+			if !ok {
+				continue
+			}
+			fd.Hunks = append(fd.Hunks, h)
+			cur = &fd.Hunks[len(fd.Hunks)-1]
+		case cur != nil:
+			cur.Lines = append(cur.Lines, line)
+		case strings.HasPrefix(line, "Binary files"):
+			fd.Binary = true
+		case line != "":
+			fd.Header = append(fd.Header, line)
+		}
+	}
+	return fd
+}
+
+// parseHunkHeader parses "@@ -a,b +c,d @@ section"
+func parseHunkHeader(line string) (Hunk, bool) {
+	var h Hunk
+
+	rest := strings.TrimPrefix(line, "@@ ")
+	ranges, section, ok := strings.Cut(rest, " @@")
+	if !ok {
+		return h, false
+	}
+	h.Section = strings.TrimSpace(section)
+
+	oldR, newR, ok := strings.Cut(ranges, " ")
+	if !ok {
+		return h, false
+	}
+
+	h.OldStart, h.OldLines = parseRange(strings.TrimPrefix(oldR, "-"))
+	h.NewStart, h.NewLines = parseRange(strings.TrimPrefix(newR, "+"))
+	return h, true
+}
+
+func parseRange(r string) (start, count int) {
+	s, c, hasCount := strings.Cut(r, ",")
+	start, _ = strconv.Atoi(s)
+	count = 1
+	if hasCount {
+		count, _ = strconv.Atoi(c)
+	}
+	return start, count
+}
+
+/*
+SplitHunk splits a hunk at unchanged lines between separate change runs
+Context between two runs is shared by both halves (same as git add -p "s").
+Returns the hunk itself when it cannot be split.
+*/
+func SplitHunk(h Hunk) []Hunk {
+	n := len(h.Lines)
+	oldAt := make([]int, n+1)
+	newAt := make([]int, n+1)
+	isChange := make([]bool, n)
+
+	oldLn, newLn := h.OldStart, h.NewStart
+	for i, line := range h.Lines {
+		oldAt[i], newAt[i] = oldLn, newLn
+
+		switch {
+		case strings.HasPrefix(line, "-"):
+			isChange[i] = true
+			oldLn++
+		case strings.HasPrefix(line, "+"):
+			isChange[i] = true
+			newLn++
+		case strings.HasPrefix(line, "\\"):
+			// "\ No newline at end of file" belongs to the previous line
+			isChange[i] = i > 0 && isChange[i-1]
+		default:
+			oldLn++
+			newLn++
+		}
+	}
+	oldAt[n], newAt[n] = oldLn, newLn
+
+	// change runs: [start, end)
+	var runs [][2]int
+	for i := 0; i < n; i++ {
+		if !isChange[i] {
+			continue
+		}
+		start := i
+		for i < n && isChange[i] {
+			i++
+		}
+		runs = append(runs, [2]int{start, i})
+	}
+
+	if len(runs) < 2 {
+		return []Hunk{h}
+	}
+
+	parts := make([]Hunk, 0, len(runs))
+	for k := range runs {
+		from, to := 0, n
+		if k > 0 {
+			from = runs[k-1][1]
+		}
+		if k < len(runs)-1 {
+			to = runs[k+1][0]
+		}
+
+		part := Hunk{
+			OldStart: oldAt[from],
+			NewStart: newAt[from],
+			Lines:    append([]string(nil), h.Lines[from:to]...),
+		}
+		if k == 0 {
+			part.Section = h.Section
+		}
+		part.OldLines, part.NewLines = countLines(part.Lines)
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+func countLines(lines []string) (oldN, newN int) {
+	for _, l := range lines {
+		switch {
+		case strings.HasPrefix(l, "-"):
+			oldN++
+		case strings.HasPrefix(l, "+"):
+			newN++
+		case strings.HasPrefix(l, "\\"):
+		default:
+			oldN++
+			newN++
+		}
+	}
+	return oldN, newN
+}
+
+/*
+Patch renders a patch containing only the given hunks
+Split parts that overlap (shared context) or touch are merged back into
+one hunk like git add -p does; overlapping hunks would not apply
+*/
+func (fd FileDiff) Patch(hunks []Hunk) string {
+	var b strings.Builder
+	for _, h := range fd.Header {
+		b.WriteString(h + "\n")
+	}
+	for _, h := range mergeHunks(hunks) {
+		b.WriteString(h.Header() + "\n")
+		for _, l := range h.Lines {
+			b.WriteString(l + "\n")
+		}
+	}
+	return b.String()
+}
+
+// mergeHunks sorts hunks by position and joins the ones that overlap or touch
+func mergeHunks(hunks []Hunk) []Hunk {
+	sorted := append([]Hunk(nil), hunks...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].OldStart < sorted[j].OldStart })
+
+	var merged []Hunk
+	for _, h := range sorted {
+		if len(merged) == 0 {
+			merged = append(merged, h)
+			continue
+		}
+
+		last := &merged[len(merged)-1]
+		shared := last.OldStart + last.OldLines - h.OldStart
+		if shared < 0 {
+			merged = append(merged, h)
+			continue
+		}
+
+		// drop the leading context h shares with last
+		skip := 0
+		for covered := 0; covered < shared && skip < len(h.Lines); skip++ {
+			if !strings.HasPrefix(h.Lines[skip], "+") && !strings.HasPrefix(h.Lines[skip], "\\") {
+				covered++
+			}
+		}
+
+		last.Lines = append(append([]string(nil), last.Lines...), h.Lines[skip:]...)
+		last.OldLines, last.NewLines = countLines(last.Lines)
+	}
+	return merged
+}
+
+/*
+StageHunks adds the chosen hunks of fd to the index
+Hunks must come from fd (optionally split); the work tree is not touched
+*/
+func StageHunks(fd FileDiff, hunks []Hunk) (Result, error) {
+	res := newResult("stage-hunks")
+
+	if len(hunks) == 0 {
+		return res, nil
+	}
+
+	args := []string{"apply", "--cached", "--recount", "-"}
+	resp, err := system.Git(system.Request{
+		Args:  args,
+		Stdin: strings.NewReader(fd.Patch(hunks)),
+	})
+	if err != nil {
+		system.LogError("git apply --cached "+fd.Path, err)
+		return res, gitErr(err, resp.Stderr, args...)
+	}
+
+	res.addRef(fd.Path)
+	res.Summary = fmt.Sprintf("Staged %d hunk(s) of %s", len(hunks), fd.Path)
+	return res, nil
+}
+
+/*
+StagePaths makes the index contain exactly the selected paths
+(everything else is unstaged, the work tree is untouched)
+*/
+func StagePaths(paths []string) (Result, error) {
+	res := newResult("stage")
+
+	if !system.IsGitRepo() {
+		return res, ErrNotRepo
+	}
+
+	err := res.stage(paths, hasAnyCommit())
+	if err == ErrNothingToCommit {
+		err = nil
+	}
+	return res, err
+}
+
+// StageFile adds the whole file (including deletion) to the index
+func StageFile(path string) (Result, error) {
+	res := newResult("stage")
+
+	if err := res.git("add", "-A", "--", path); err != nil {
+		return res, err
+	}
+	res.addRef(path)
+	return res, nil
+}
+
+// UnstagePath removes path from the index (keeps work tree changes)
+func UnstagePath(path string) (Result, error) {
+	res := newResult("unstage")
+
+	args := []string{"reset", "-q", "--", path}
+	if !hasAnyCommit() {
+		args = []string{"rm", "--cached", "-q", "--", path}
+	}
+
+	if err := res.git(args...); err != nil {
+		return res, err
+	}
+	res.addRef(path)
+	return res, nil
+}
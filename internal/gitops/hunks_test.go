@@ -0,0 +1,240 @@
+package gitops
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"git-genius/internal/system"
+)
+
+const twoChangeDiff = `diff --git a/list.txt b/list.txt
+index 1111111..2222222 100644
+--- a/list.txt
++++ b/list.txt
+@@ -1,7 +1,7 @@ header
+ one
+-two
++TWO
+ three
+ four
+ five
+-six
++SIX
+ seven
+`
+
+func TestParseFileDiff(t *testing.T) {
+	fd := ParseFileDiff("list.txt", twoChangeDiff)
+
+	wantHeader := []string{
+		"diff --git a/list.txt b/list.txt",
+		"index 1111111..2222222 100644",
+		"--- a/list.txt",
+		"+++ b/list.txt",
+	}
+	if !reflect.DeepEqual(fd.Header, wantHeader) {
+		t.Errorf("Header = %q", fd.Header)
+	}
+	if len(fd.Hunks) != 1 {
+		t.Fatalf("got %d hunks, want 1", len(fd.Hunks))
+	}
+
+	h := fd.Hunks[0]
+	if h.OldStart != 1 || h.OldLines != 7 || h.NewStart != 1 || h.NewLines != 7 || h.Section != "header" {
+		t.Errorf("hunk = %+v", h)
+	}
+	if len(h.Lines) != 9 {
+		t.Errorf("got %d lines, want 9", len(h.Lines))
+	}
+}
+
+func TestParseFileDiffBinary(t *testing.T) {
+	fd := ParseFileDiff("logo.png", "diff --git a/logo.png b/logo.png\nBinary files a/logo.png and b/logo.png differ\n")
+	if !fd.Binary || len(fd.Hunks) != 0 {
+		t.Errorf("ParseFileDiff() = %+v, want binary without hunks", fd)
+	}
+}
+
+func TestParseHunkHeader(t *testing.T) {
+	tests := []struct {
+		line string
+		want Hunk
+		ok   bool
+	}{
+		{"@@ -1,3 +1,4 @@", Hunk{OldStart: 1, OldLines: 3, NewStart: 1, NewLines: 4}, true},
+		{"@@ -5 +5 @@ func main()", Hunk{OldStart: 5, OldLines: 1, NewStart: 5, NewLines: 1, Section: "func main()"}, true},
+		{"@@ -0,0 +1,2 @@", Hunk{OldStart: 0, OldLines: 0, NewStart: 1, NewLines: 2}, true},
+		{"@@ broken", Hunk{}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseHunkHeader(tt.line)
+		if ok != tt.ok || (ok && !reflect.DeepEqual(got, tt.want)) {
+			t.Errorf("parseHunkHeader(%q) = %+v, %v; want %+v, %v", tt.line, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestSplitHunk(t *testing.T) {
+	h := ParseFileDiff("list.txt", twoChangeDiff).Hunks[0]
+
+	tests := []struct {
+		name string
+		hunk Hunk
+		want []Hunk
+	}{
+		{
+			name: "two change runs share the context between them",
+			hunk: h,
+			want: []Hunk{
+				{OldStart: 1, OldLines: 5, NewStart: 1, NewLines: 5, Section: "header",
+					Lines: []string{" one", "-two", "+TWO", " three", " four", " five"}},
+				{OldStart: 3, OldLines: 5, NewStart: 3, NewLines: 5,
+					Lines: []string{" three", " four", " five", "-six", "+SIX", " seven"}},
+			},
+		},
+		{
+			name: "single run is not split",
+			hunk: Hunk{OldStart: 1, OldLines: 2, NewStart: 1, NewLines: 2, Lines: []string{" a", "-b", "+B"}},
+			want: []Hunk{{OldStart: 1, OldLines: 2, NewStart: 1, NewLines: 2, Lines: []string{" a", "-b", "+B"}}},
+		},
+		{
+			name: "no newline marker stays with its line",
+			hunk: Hunk{OldStart: 1, OldLines: 3, NewStart: 1, NewLines: 3,
+				Lines: []string{"-a", "+A", " b", "-c", `\ No newline at end of file`, "+C", `\ No newline at end of file`}},
+			want: []Hunk{
+				{OldStart: 1, OldLines: 2, NewStart: 1, NewLines: 2, Lines: []string{"-a", "+A", " b"}},
+				{OldStart: 2, OldLines: 2, NewStart: 2, NewLines: 2,
+					Lines: []string{" b", "-c", `\ No newline at end of file`, "+C", `\ No newline at end of file`}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := SplitHunk(tt.hunk)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SplitHunk()\n got %+v\nwant %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStagePathsKeepsPickedStaging(t *testing.T) {
+	tests := []struct {
+		name       string
+		hasCommits bool
+		staged     string
+		want       []string
+	}{
+		{
+			name:       "only unpicked paths are unstaged",
+			hasCommits: true,
+			staged:     "a.go\x00old.go\x00",
+			want:       []string{"reset -q -- old.go", "add -A -- a.go b.go"},
+		},
+		{
+			name:       "everything staged was picked",
+			hasCommits: true,
+			staged:     "a.go\x00",
+			want:       []string{"add -A -- a.go b.go"},
+		},
+		{
+			name:   "before the first commit",
+			staged: "a.go\x00old.go\x00",
+			want:   []string{"rm --cached -q -- old.go", "add -A -- a.go b.go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := useFake(t)
+			fake.On("diff --cached --name-only --no-renames -z", system.Response{Stdout: tt.staged})
+			if !tt.hasCommits {
+				fake.On("log -1", system.Response{ExitCode: 128})
+			}
+
+			if _, err := StagePaths([]string{"a.go", "b.go"}); err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, c := range fake.Commands() {
+				if strings.HasPrefix(c, "reset") || strings.HasPrefix(c, "rm") || strings.HasPrefix(c, "add") {
+					got = append(got, c)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("index commands = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPatchMergesSplitParts(t *testing.T) {
+	fd := ParseFileDiff("list.txt", twoChangeDiff)
+	parts := SplitHunk(fd.Hunks[0])
+
+	// both parts back together = the original hunk (the section stays)
+	if got, want := fd.Patch(parts), fd.Patch(fd.Hunks); got != want {
+		t.Errorf("Patch(all parts)\n got:\n%s\nwant:\n%s", got, want)
+	}
+	// order of choice does not matter
+	if got, want := fd.Patch([]Hunk{parts[1], parts[0]}), fd.Patch(fd.Hunks); got != want {
+		t.Errorf("Patch(reversed parts)\n got:\n%s\nwant:\n%s", got, want)
+	}
+	// a single part is written as it is
+	if got := fd.Patch(parts[1:]); !strings.Contains(got, parts[1].Header()+"\n") {
+		t.Errorf("Patch(second part) = \n%s", got)
+	}
+}
+
+func TestStageSplitHunksApplies(t *testing.T) {
+	dir, git := tempRepo(t)
+
+	write := func(lines ...string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, "list.txt"), []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("a", "b", "c", "d", "e", "f", "g", "h", "i")
+	git("add", "list.txt")
+	git("commit", "-q", "-m", "list")
+	// three change runs, one line apart: a single hunk that splits in three
+	write("a", "B", "c", "D", "d2", "e", "g", "h", "i")
+
+	fd, err := UnstagedHunks("list.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fd.Hunks) != 1 {
+		t.Fatalf("got %d hunks, want 1", len(fd.Hunks))
+	}
+	parts := SplitHunk(fd.Hunks[0])
+	if len(parts) != 3 {
+		t.Fatalf("got %d parts, want 3", len(parts))
+	}
+
+	// every combination of parts must apply
+	for mask := 1; mask < 1<<len(parts); mask++ {
+		var chosen []Hunk
+		for i, p := range parts {
+			if mask&(1<<i) != 0 {
+				chosen = append(chosen, p)
+			}
+		}
+
+		git("reset", "-q")
+		if _, err := StageHunks(fd, chosen); err != nil {
+			t.Fatalf("parts %03b: %v\npatch:\n%s", mask, err, fd.Patch(chosen))
+		}
+		if mask == 1<<len(parts)-1 && git("diff", "--name-only") != "" {
+			t.Errorf("all parts staged, work tree still differs:\n%s", git("diff"))
+		}
+	}
+}
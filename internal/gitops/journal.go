@@ -0,0 +1,634 @@
+package gitops
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"git-genius/internal/config"
+	"git-genius/internal/system"
+)
+
+/* ============================================================
+   OPERATION JOURNAL (.git/.genius/journal.jsonl)
+   ============================================================
+
+   Every mutating git-genius operation that changes HEAD, the
+   branch or the stash list is recorded, successful or not.
+   Operations in treeOps record the index and work tree too,
+   operations in refOps the branches and tags they create or delete.
+   UndoOperation reverts the latest entry to its pre-state.
+*/
+
+// journalFile holds one JSON entry per line (oldest first) in .git/.genius
+func journalFile() string {
+	return filepath.Join(system.GitDir(), ".genius", "journal.jsonl")
+}
+
+// journalLimit keeps the file small
+const journalLimit = 200
+
+// JournalEntry is one recorded operation
+type JournalEntry struct {
+	Time         time.Time `json:"time"`
+	Operation    string    `json:"operation"`
+	Branch       string    `json:"branch"` // before ("-" = detached)
+	BranchAfter  string    `json:"branch_after"`
+	HeadBefore   string    `json:"head_before"`
+	HeadAfter    string    `json:"head_after"`
+	StashCreated []string  `json:"stash_created,omitempty"` // still on the stash list afterwards
+	StashRemoved []string  `json:"stash_removed,omitempty"` // popped / dropped
+	Pushed       string    `json:"pushed,omitempty"`        // remote/branch when commits left the machine
+	Failed       bool      `json:"failed,omitempty"`
+	Undone       bool      `json:"undone,omitempty"`
+
+	// index + work tree, recorded for treeOps only
+	WorkTree       bool              `json:"work_tree,omitempty"`
+	TreeBefore     string            `json:"tree_before,omitempty"`     // git stash create ("" = clean)
+	TreeAfter      string            `json:"tree_after,omitempty"`      // git stash create ("" = clean)
+	UntrackedAdded map[string]string `json:"untracked_added,omitempty"` // path → blob hash
+
+	// branches + tags (refname → hash), recorded for refOps only
+	RefsCreated map[string]string `json:"refs_created,omitempty"`
+	RefsDeleted map[string]string `json:"refs_deleted,omitempty"`
+}
+
+// treeOps change the index or work tree (not only HEAD / stashes)
+var treeOps = map[string]bool{
+	"stash-pop":    true,
+	"stash-apply":  true,
+	"stash-branch": true,
+	"untrack":      true,
+	"ignore":       true,
+	"lfs-track":    true,
+}
+
+// refOps create or delete branches / tags other than the current branch
+var refOps = map[string]bool{
+	"create-tag":       true,
+	"rename-branch":    true,
+	"delete-branch":    true,
+	"cleanup-branches": true,
+}
+
+// snapshot is the state the journal compares
+type snapshot struct {
+	branch    string
+	head      string
+	stashes   []string
+	tree      string   // index + tracked changes as a stash commit ("" = clean)
+	untracked []string // not ignored
+	refs      map[string]string
+}
+
+// readRefs maps local branches and tags to their hashes
+func readRefs() map[string]string {
+	out, _ := system.GitRaw("for-each-ref", "--format=%(refname) %(objectname)", "refs/heads", "refs/tags")
+
+	refs := map[string]string{}
+	for _, l := range splitLines(out) {
+		if ref, hash, ok := strings.Cut(l, " "); ok {
+			refs[ref] = hash
+		}
+	}
+	return refs
+}
+
+// refsMissing returns the refs of a that b does not have (with a's hashes)
+func refsMissing(a, b map[string]string) map[string]string {
+	out := map[string]string{}
+	for ref, hash := range a {
+		if _, ok := b[ref]; !ok {
+			out[ref] = hash
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// takeSnapshot reads branch, HEAD and stashes; withTree adds index and work tree
+func takeSnapshot(withTree bool) snapshot {
+	head, _ := system.GitRaw("log", "-1", "--format=%H")
+	stashes, _ := system.GitRaw("stash", "list", "--format=%H")
+
+	s := snapshot{
+		branch:  CurrentBranch(),
+		head:    strings.TrimSpace(head),
+		stashes: splitLines(stashes),
+	}
+	if withTree {
+		// stash create stores the state without touching it (and without a stash entry)
+		tree, _ := system.GitRaw("stash", "create")
+		untracked, _ := system.GitRaw("ls-files", "--others", "--exclude-standard", "-z")
+		s.tree = strings.TrimSpace(tree)
+		for _, p := range strings.Split(untracked, "\x00") {
+			if p != "" {
+				s.untracked = append(s.untracked, p)
+			}
+		}
+	}
+	return s
+}
+
+/*
+sameTree compares two git stash create commits by content
+(the commits themselves differ by date)
+*/
+func sameTree(a, b string) bool {
+	if a == "" || b == "" {
+		return a == b
+	}
+	ta, errA := system.GitRaw("rev-parse", a+"^{tree}", a+"^2^{tree}")
+	tb, errB := system.GitRaw("rev-parse", b+"^{tree}", b+"^2^{tree}")
+	return errA == nil && errB == nil && ta == tb
+}
+
+// fileHashes returns the blob hash of each file as git would store it
+func fileHashes(paths []string) map[string]string {
+	if len(paths) == 0 {
+		return nil
+	}
+
+	out, err := system.GitRaw(append([]string{"hash-object", "--"}, paths...)...)
+	hashes := splitLines(out)
+	if err != nil || len(hashes) != len(paths) {
+		return nil
+	}
+
+	m := make(map[string]string, len(paths))
+	for i, p := range paths {
+		m[p] = hashes[i]
+	}
+	return m
+}
+
+/*
+journaled runs op and records it when HEAD, branch or stash list changed
+(for treeOps also when the index or work tree changed)
+*/
+func journaled(name string, op func() (Result, error)) (Result, error) {
+	if !system.IsGitRepo() {
+		return op()
+	}
+
+	withTree, withRefs := treeOps[name], refOps[name]
+	before := takeSnapshot(withTree)
+	if withRefs {
+		before.refs = readRefs()
+	}
+	res, err := op()
+	after := takeSnapshot(withTree)
+	if withRefs {
+		after.refs = readRefs()
+	}
+
+	e := JournalEntry{
+		Time:         time.Now(),
+		Operation:    name,
+		Branch:       before.branch,
+		BranchAfter:  after.branch,
+		HeadBefore:   before.head,
+		HeadAfter:    after.head,
+		StashCreated: missingFrom(after.stashes, before.stashes),
+		StashRemoved: missingFrom(before.stashes, after.stashes),
+		Failed:       err != nil,
+	}
+
+	if name == "push" && err == nil && len(res.Refs) > 1 {
+		e.Pushed = res.Refs[1]
+	}
+
+	treeChanged := false
+	if withTree {
+		e.WorkTree = true
+		e.TreeBefore, e.TreeAfter = before.tree, after.tree
+		e.UntrackedAdded = fileHashes(missingFrom(after.untracked, before.untracked))
+		treeChanged = len(e.UntrackedAdded) > 0 || !sameTree(e.TreeBefore, e.TreeAfter)
+	}
+	if withRefs {
+		e.RefsCreated = refsMissing(after.refs, before.refs)
+		e.RefsDeleted = refsMissing(before.refs, after.refs)
+	}
+
+	if e.Branch != e.BranchAfter || e.HeadBefore != e.HeadAfter ||
+		len(e.StashCreated) > 0 || len(e.StashRemoved) > 0 || e.Pushed != "" || treeChanged ||
+		len(e.RefsCreated) > 0 || len(e.RefsDeleted) > 0 {
+		appendJournal(e)
+	}
+	return res, err
+}
+
+// missingFrom returns items of a that are not in b
+func missingFrom(a, b []string) []string {
+	in := map[string]bool{}
+	for _, x := range b {
+		in[x] = true
+	}
+
+	var out []string
+	for _, x := range a {
+		if !in[x] {
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
+/* ============================================================
+   STORAGE
+   ============================================================ */
+
+// Journal returns recorded operations, newest first
+func Journal() []JournalEntry {
+	entries := readJournal()
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries
+}
+
+func readJournal() []JournalEntry {
+	f, err := os.Open(journalFile())
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var entries []JournalEntry
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var e JournalEntry
+		if json.Unmarshal(sc.Bytes(), &e) == nil {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+func appendJournal(e JournalEntry) {
+	writeJournal(append(readJournal(), e))
+}
+
+// writeJournal rewrites the file (best effort, like config.Save)
+func writeJournal(entries []JournalEntry) {
+	if len(entries) > journalLimit {
+		entries = entries[len(entries)-journalLimit:]
+	}
+
+	var b strings.Builder
+	for _, e := range entries {
+		line, err := json.Marshal(e)
+		if err != nil {
+			continue
+		}
+		b.Write(line)
+		b.WriteString("\n")
+	}
+
+	file := journalFile()
+	_ = os.MkdirAll(filepath.Dir(file), 0700)
+	_ = os.WriteFile(file, []byte(b.String()), 0600)
+}
+
+/* ============================================================
+   UNDO
+   ============================================================ */
+
+// LastOperation returns the newest entry that was not undone yet
+func LastOperation() (JournalEntry, bool) {
+	for _, e := range Journal() {
+		if !e.Undone {
+			return e, true
+		}
+	}
+	return JournalEntry{}, false
+}
+
+// Diverged reports whether the repository moved on since e
+func (e JournalEntry) Diverged() bool {
+	now := takeSnapshot(e.WorkTree)
+	if now.head != e.HeadAfter || now.branch != e.BranchAfter {
+		return true
+	}
+	if len(e.RefsCreated) > 0 || len(e.RefsDeleted) > 0 {
+		refs := readRefs()
+		for ref, hash := range e.RefsCreated {
+			if refs[ref] != hash {
+				return true
+			}
+		}
+		for ref := range e.RefsDeleted {
+			if _, ok := refs[ref]; ok {
+				return true
+			}
+		}
+	}
+	return e.WorkTree && !sameTree(now.tree, e.TreeAfter)
+}
+
+/*
+UndoOperation reverts the latest journal entry:
+  - back to the previous branch and HEAD (backup ref first, see undoResetMode)
+  - index and work tree back to their recorded state (treeOps, see clearWorkTree)
+  - stashes the operation created are popped (changes return to the work tree)
+  - stashes it removed are stored again
+  - branches / tags it deleted come back, the ones it created are deleted
+
+Diverged repository + !force → ErrStateChanged
+*/
+func UndoOperation(force bool) (Result, error) {
+	res := newResult("undo-operation")
+
+	if !system.IsGitRepo() {
+		return res, ErrNotRepo
+	}
+
+	e, ok := LastOperation()
+	if !ok {
+		return res, ErrNothingToUndo
+	}
+	if e.HeadBefore == "" {
+		return res, fmt.Errorf("%w: %s created the first commit (use Undo last commit)", ErrNothingToUndo, e.Operation)
+	}
+	if e.Diverged() && !force {
+		return res, ErrStateChanged
+	}
+
+	var created map[string]string
+	if e.WorkTree {
+		var err error
+		if created, err = createdFiles(e); err != nil {
+			return res, err
+		}
+	}
+
+	backup, err := CreateBackup(res.Operation)
+	if err != nil {
+		return res, err
+	}
+	res.addRef(backup)
+
+	if e.WorkTree {
+		if err := res.clearWorkTree(e, created); err != nil {
+			return res, err
+		}
+	} else if st, err := ReadStatus(); err == nil && len(st.Conflicted) > 0 {
+		// unfinished merge / conflicted stash pop
+		if err := res.git("reset", "--merge"); err != nil {
+			return res, err
+		}
+	}
+
+	// before the checkout: the previous branch may be one of them
+	now := readRefs()
+	for _, ref := range sortedRefs(e.RefsDeleted) {
+		if _, ok := now[ref]; ok {
+			res.warn(shortRefName(ref) + " exists again, left as it is")
+			continue
+		}
+		if err := res.git("update-ref", "-m", "git-genius undo ("+e.Operation+")", ref, e.RefsDeleted[ref]); err != nil {
+			return res, err
+		}
+	}
+
+	if e.Branch != CurrentBranch() {
+		target := e.Branch
+		if target == "-" {
+			target = e.HeadBefore
+		}
+		if err := res.git("checkout", target); err != nil {
+			return res, err
+		}
+	}
+
+	if takeSnapshot(false).head != e.HeadBefore {
+		if err := res.git("reset", undoResetMode(e.Operation), e.HeadBefore); err != nil {
+			return res, err
+		}
+	}
+
+	if e.WorkTree {
+		// created files the previous HEAD still tracked
+		if err := removeUntracked(created); err != nil {
+			return res, err
+		}
+		if e.TreeBefore != "" {
+			if err := res.git("stash", "apply", "--index", "-q", e.TreeBefore); err != nil {
+				return res, err
+			}
+		}
+	}
+
+	for _, h := range e.StashCreated {
+		ref, found := stashRefFor(h)
+		if !found {
+			continue
+		}
+		if err := res.git("stash", "pop", ref); err != nil {
+			return res, err
+		}
+	}
+
+	for _, h := range e.StashRemoved {
+		msg, _ := system.GitOutput("log", "-1", "--format=%s", h)
+		if err := res.git("stash", "store", "-m", msg, h); err != nil {
+			return res, err
+		}
+		if !e.WorkTree && e.Operation != "stash-drop" {
+			// entries recorded before the work tree was journaled
+			res.warn("Stash restored; its changes are also still in your work tree")
+		}
+	}
+
+	for _, ref := range sortedRefs(e.RefsCreated) {
+		if now[ref] != e.RefsCreated[ref] {
+			res.warn(shortRefName(ref) + " moved since " + e.Operation + ", left as it is")
+			continue
+		}
+		if err := res.git("update-ref", "-d", ref, e.RefsCreated[ref]); err != nil {
+			return res, err
+		}
+	}
+
+	if e.Operation == "rename-branch" {
+		for renamed := range e.RefsCreated {
+			for old := range e.RefsDeleted {
+				followRename(shortRefName(renamed), shortRefName(old))
+			}
+		}
+	}
+
+	if e.Pushed != "" {
+		res.warn("Commits were already pushed to " + e.Pushed + " (remote unchanged)")
+	}
+
+	markUndone(e)
+	res.addRef(e.Branch)
+	res.Summary = "Undid " + e.Operation + " from " + e.Time.Format("2006-01-02 15:04") + " (backup: " + backup + ")"
+	return res, nil
+}
+
+/*
+createdFiles lists files the undo of e deletes from the work tree,
+path → the content they must still have:
+  - untracked files the operation created (e.g. from a stash with -u)
+  - files only e.TreeBefore has; untracked now, they would block its apply
+
+A file changed since then → ErrStateChanged (nothing was touched yet)
+*/
+func createdFiles(e JournalEntry) (map[string]string, error) {
+	files := map[string]string{}
+	for p, h := range e.UntrackedAdded {
+		files[p] = h
+	}
+
+	if e.TreeBefore != "" {
+		// nothing references the snapshot: git gc removes it after a while
+		if !system.GitOK("cat-file", "-e", e.TreeBefore+"^{commit}") {
+			return nil, fmt.Errorf("%w: the work tree recorded before %s is no longer stored", ErrStateChanged, e.Operation)
+		}
+		out, _ := system.GitRaw("diff", "--name-only", "--no-renames", "--diff-filter=A", "-z", e.TreeBefore+"^1", e.TreeBefore)
+		for _, p := range strings.Split(out, "\x00") {
+			if p == "" {
+				continue
+			}
+			if h, err := system.GitOutput("rev-parse", e.TreeBefore+":"+p); err == nil {
+				files[p] = h
+			}
+		}
+	}
+
+	dir := config.Load().GetWorkDir()
+	var present []string
+	for p := range files {
+		if _, err := os.Lstat(filepath.Join(dir, p)); err == nil {
+			present = append(present, p)
+		} else {
+			delete(files, p)
+		}
+	}
+	sort.Strings(present)
+
+	now := fileHashes(present)
+	for _, p := range present {
+		if now[p] != files[p] {
+			return nil, fmt.Errorf("%w: %s was changed after %s", ErrStateChanged, p, e.Operation)
+		}
+	}
+	return files, nil
+}
+
+/*
+clearWorkTree empties index and work tree before the recorded state is applied
+Changes made after e are stored on the stash list first; untracked created
+files (see createdFiles) are deleted, their content is in a stash or in e.TreeBefore
+*/
+func (r *Result) clearWorkTree(e JournalEntry, created map[string]string) error {
+	now := takeSnapshot(true)
+	if now.tree != "" && !sameTree(now.tree, e.TreeAfter) {
+		branch := now.branch
+		if branch == "-" {
+			branch = "(no branch)"
+		}
+		msg := "On " + branch + ": git-genius backup (undo of " + e.Operation + ")"
+		if err := r.git("stash", "store", "-m", msg, now.tree); err != nil {
+			return err
+		}
+		r.warn("Changes made after " + e.Operation + " were saved to the stash list (git-genius backup)")
+	}
+
+	if err := r.git("reset", "-q", "--hard"); err != nil {
+		return err
+	}
+	return removeUntracked(created)
+}
+
+// removeUntracked deletes those files git does not track at the moment
+func removeUntracked(files map[string]string) error {
+	if len(files) == 0 {
+		return nil
+	}
+
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	out, _ := system.GitRaw(append([]string{"ls-files", "-z", "--"}, paths...)...)
+	tracked := map[string]bool{}
+	for _, p := range strings.Split(out, "\x00") {
+		tracked[p] = true
+	}
+
+	dir := config.Load().GetWorkDir()
+	for _, p := range paths {
+		if tracked[p] {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, p)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// sortedRefs returns the refnames of refs in a stable order
+func sortedRefs(refs map[string]string) []string {
+	names := make([]string, 0, len(refs))
+	for ref := range refs {
+		names = append(names, ref)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// shortRefName drops refs/heads/ and refs/tags/
+func shortRefName(ref string) string {
+	return strings.TrimPrefix(strings.TrimPrefix(ref, "refs/heads/"), "refs/tags/")
+}
+
+/*
+undoResetMode picks how HEAD moves back:
+commits made from the work tree return to it (push: unstaged, undo: staged),
+everything else (pulls, restores) must not leave incoming changes behind
+*/
+func undoResetMode(op string) string {
+	switch op {
+	case "push":
+		return "--mixed"
+	case "undo":
+		return "--soft"
+	}
+	return "--keep"
+}
+
+// stashRefFor finds stash@{n} for a stash commit hash
+func stashRefFor(hash string) (string, bool) {
+	out, _ := system.GitRaw("stash", "list", "--format=%gd %H")
+	for _, l := range splitLines(out) {
+		ref, h, _ := strings.Cut(l, " ")
+		if h == hash {
+			return ref, true
+		}
+	}
+	return "", false
+}
+
+func markUndone(e JournalEntry) {
+	entries := readJournal()
+	for i := len(entries) - 1; i >= 0; i-- {
+		if entries[i].Time.Equal(e.Time) && entries[i].Operation == e.Operation {
+			entries[i].Undone = true
+			break
+		}
+	}
+	writeJournal(entries)
+}
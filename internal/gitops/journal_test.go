@@ -0,0 +1,222 @@
+package gitops
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// journalRepo is a tempRepo with a.txt and b.txt committed
+func journalRepo(t *testing.T) (write func(name, content string), read func(name string) string, git func(args ...string) string) {
+	t.Helper()
+
+	dir, git := tempRepo(t)
+	write = func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	read = func(name string) string {
+		b, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return "<missing>"
+		}
+		return string(b)
+	}
+
+	write("a.txt", "a\n")
+	write("b.txt", "b\n")
+	git("add", ".")
+	git("commit", "-q", "-m", "init")
+	return write, read, git
+}
+
+func TestUndoStashPopRestoresWorkTree(t *testing.T) {
+	write, read, git := journalRepo(t)
+
+	write("a.txt", "stashed\n")
+	write("new.txt", "untracked\n")
+	git("stash", "push", "-q", "-u")
+	stash := git("rev-parse", "stash@{0}")
+	write("b.txt", "local\n") // unrelated change made before the pop
+
+	if _, err := StashPop(); err != nil {
+		t.Fatal(err)
+	}
+	if read("a.txt") != "stashed\n" || read("new.txt") != "untracked\n" {
+		t.Fatal("stash was not applied")
+	}
+
+	if _, err := UndoOperation(false); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := read("a.txt"); got != "a\n" {
+		t.Errorf("a.txt = %q, want the committed version", got)
+	}
+	if got := read("new.txt"); got != "<missing>" {
+		t.Errorf("new.txt = %q, want it removed", got)
+	}
+	if got := read("b.txt"); got != "local\n" {
+		t.Errorf("b.txt = %q, want the change made before the pop", got)
+	}
+	if got := git("stash", "list", "--format=%H"); got != stash {
+		t.Errorf("stash list = %q, want only %q", got, stash)
+	}
+}
+
+func TestUndoStashPopKeepsLaterChanges(t *testing.T) {
+	write, read, git := journalRepo(t)
+
+	write("a.txt", "stashed\n")
+	git("stash", "push", "-q")
+	if _, err := StashPop(); err != nil {
+		t.Fatal(err)
+	}
+	write("b.txt", "after the pop\n")
+
+	if _, err := UndoOperation(false); !errors.Is(err, ErrStateChanged) {
+		t.Fatalf("UndoOperation(false) error = %v, want ErrStateChanged", err)
+	}
+	res, err := UndoOperation(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if read("a.txt") != "a\n" || read("b.txt") != "b\n" {
+		t.Errorf("work tree not reset: a.txt %q, b.txt %q", read("a.txt"), read("b.txt"))
+	}
+	if len(res.Warnings) == 0 {
+		t.Error("no warning about the saved changes")
+	}
+	// popped stash on top again, the backup of the later changes below it
+	if got := git("show", "stash@{1}:b.txt"); got != "after the pop\n" {
+		t.Errorf("backup stash has b.txt = %q", got)
+	}
+	if got := git("show", "stash@{0}:a.txt"); got != "stashed\n" {
+		t.Errorf("restored stash has a.txt = %q", got)
+	}
+}
+
+func TestUndoRefusesChangedUntrackedFile(t *testing.T) {
+	write, read, git := journalRepo(t)
+
+	write("new.txt", "untracked\n")
+	git("stash", "push", "-q", "-u")
+	if _, err := StashPop(); err != nil {
+		t.Fatal(err)
+	}
+	write("new.txt", "edited\n")
+
+	if _, err := UndoOperation(true); !errors.Is(err, ErrStateChanged) {
+		t.Fatalf("UndoOperation(true) error = %v, want ErrStateChanged", err)
+	}
+	if got := read("new.txt"); got != "edited\n" {
+		t.Errorf("new.txt = %q, want it untouched", got)
+	}
+}
+
+func TestUndoUntrackStagesAgain(t *testing.T) {
+	write, read, git := journalRepo(t)
+
+	write("big.bin", "large\n")
+	write("a.txt", "changed\n")
+	git("add", ".")
+
+	if _, err := UntrackPaths([]string{"big.bin", "a.txt"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := git("diff", "--cached", "--name-only"); got != "" {
+		t.Fatalf("staged after untrack: %q", got)
+	}
+
+	e, ok := LastOperation()
+	if !ok || e.Operation != "untrack" || !e.WorkTree {
+		t.Fatalf("LastOperation() = %+v, %v; want a work tree entry for untrack", e, ok)
+	}
+	if _, err := UndoOperation(false); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := strings.Fields(git("diff", "--cached", "--name-only")); strings.Join(got, " ") != "a.txt big.bin" {
+		t.Errorf("staged after undo = %q, want a.txt big.bin", got)
+	}
+	if read("a.txt") != "changed\n" || read("big.bin") != "large\n" {
+		t.Errorf("work tree changed: a.txt %q, big.bin %q", read("a.txt"), read("big.bin"))
+	}
+}
+
+func TestUndoStashBranch(t *testing.T) {
+	write, read, git := journalRepo(t)
+	branch := strings.TrimSpace(git("branch", "--show-current"))
+
+	write("a.txt", "stashed\n")
+	git("stash", "push", "-q")
+	entries, err := ReadStashes()
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("ReadStashes() = %v, %v", entries, err)
+	}
+
+	if _, err := StashBranch(entries[0], "from-stash"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := UndoOperation(false); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := CurrentBranch(); got != branch {
+		t.Errorf("branch = %q, want %q", got, branch)
+	}
+	if got := read("a.txt"); got != "a\n" {
+		t.Errorf("a.txt = %q, want the committed version", got)
+	}
+	if got := strings.TrimSpace(git("rev-parse", "stash@{0}")); got != entries[0].Hash {
+		t.Errorf("stash@{0} = %s, want %s", got, entries[0].Hash)
+	}
+}
+
+func TestUndoCreateTag(t *testing.T) {
+	_, _, git := journalRepo(t)
+
+	if _, err := CreateTag("v1.0.0", "", "", false); err != nil {
+		t.Fatal(err)
+	}
+	e, ok := LastOperation()
+	if !ok || e.Operation != "create-tag" || e.RefsCreated["refs/tags/v1.0.0"] == "" {
+		t.Fatalf("LastOperation() = %+v, %v; want the created tag", e, ok)
+	}
+	if _, err := UndoOperation(false); err != nil {
+		t.Fatal(err)
+	}
+	if got := git("tag", "--list"); got != "" {
+		t.Errorf("tags after undo = %q, want none", got)
+	}
+}
+
+func TestJournalInGitDir(t *testing.T) {
+	_, _, git := journalRepo(t)
+	gitDir := strings.TrimSpace(git("rev-parse", "--absolute-git-dir"))
+
+	if err := os.Mkdir("sub", 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir("sub"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := CreateTag("v1.0.0", "", "", false); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(filepath.Join(gitDir, ".genius", "journal.jsonl")); err != nil {
+		t.Errorf("journal not in the git dir: %v", err)
+	}
+	if _, err := os.Stat(".git"); err == nil {
+		t.Error("journal written below the current directory")
+	}
+	if _, ok := LastOperation(); !ok {
+		t.Error("LastOperation() found nothing from a subdirectory")
+	}
+}
@@ -0,0 +1,303 @@
+package gitops
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"git-genius/internal/config"
+	"git-genius/internal/system"
+)
+
+/* ============================================================
+   LARGE FILES (checked before commit / push)
+   ============================================================
+
+   GitHub warns at 50 MB and rejects files over 100 MB,
+   but only when the push is already on its way.
+*/
+
+// LargeFile is a staged file above the configured limit
+type LargeFile struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+}
+
+// FormatSize renders bytes as KB / MB
+func FormatSize(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	}
+	return strconv.FormatInt(n, 10) + " B"
+}
+
+/*
+StagedLargeFiles lists staged files larger than limit bytes
+Sizes come from the index blobs, so files already tracked by LFS
+(stored as small pointers) are never reported
+*/
+func StagedLargeFiles(limit int64) ([]LargeFile, error) {
+	names, err := system.GitRaw("diff", "--cached", "--name-only", "--diff-filter=ACMR")
+	if err != nil {
+		return nil, err
+	}
+
+	paths := splitLines(names)
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	var in strings.Builder
+	for _, p := range paths {
+		in.WriteString(":" + p + "\n")
+	}
+
+	resp, err := system.Git(system.Request{
+		Args:  []string{"cat-file", "--batch-check=%(objectsize)"},
+		Stdin: strings.NewReader(in.String()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var large []LargeFile
+	for i, l := range strings.Split(strings.TrimSpace(resp.Stdout), "\n") {
+		size, err := strconv.ParseInt(strings.TrimSpace(l), 10, 64)
+		if err != nil || i >= len(paths) {
+			continue // "missing" entries
+		}
+		if size > limit {
+			large = append(large, LargeFile{Path: paths[i], Size: size})
+		}
+	}
+	return large, nil
+}
+
+/*
+outgoingLargeFiles lists files above limit bytes in the commits of revs
+remote does not have yet (largest version per path)
+*/
+func outgoingLargeFiles(remote string, revs []string, limit int64) ([]LargeFile, error) {
+	out, err := system.GitRaw(outgoingArgs(remote, revs, "rev-list", "--objects")...)
+	if err != nil {
+		return nil, err
+	}
+
+	var hashes, paths []string
+	for _, l := range splitLines(out) {
+		hash, path, ok := strings.Cut(l, " ")
+		if !ok || path == "" {
+			continue // commits and root trees
+		}
+		hashes = append(hashes, hash)
+		paths = append(paths, path)
+	}
+	if len(hashes) == 0 {
+		return nil, nil
+	}
+
+	resp, err := system.Git(system.Request{
+		Args:  []string{"cat-file", "--batch-check=%(objecttype) %(objectsize)"},
+		Stdin: strings.NewReader(strings.Join(hashes, "\n") + "\n"),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var large []LargeFile
+	seen := map[string]int{}
+	for i, l := range strings.Split(strings.TrimSpace(resp.Stdout), "\n") {
+		kind, sizeField, _ := strings.Cut(strings.TrimSpace(l), " ")
+		size, err := strconv.ParseInt(sizeField, 10, 64)
+		if err != nil || kind != "blob" || i >= len(paths) || size <= limit {
+			continue
+		}
+		if j, ok := seen[paths[i]]; ok {
+			if size > large[j].Size {
+				large[j].Size = size
+			}
+			continue
+		}
+		seen[paths[i]] = len(large)
+		large = append(large, LargeFile{Path: paths[i], Size: size})
+	}
+	return large, nil
+}
+
+// largeFilesFound stores the list on the result and returns ErrLargeFiles
+func (r *Result) largeFilesFound(files []LargeFile) error {
+	r.Data = files
+	return fmt.Errorf("%w (limit %d MB)", ErrLargeFiles, config.Load().LargeFileMB)
+}
+
+// checkLargeFiles blocks the commit when the index holds oversized files
+func (r *Result) checkLargeFiles(opts PushOptions) error {
+	if opts.AllowLargeFiles {
+		return nil
+	}
+
+	files, err := StagedLargeFiles(int64(config.Load().LargeFileMB) << 20)
+	if err != nil {
+		return err
+	}
+	if len(files) > 0 {
+		return r.largeFilesFound(files)
+	}
+	return nil
+}
+
+/* ============================================================
+   FIXES (menu offers these for LargeFile lists)
+   ============================================================ */
+
+/*
+UntrackPaths leaves paths out of the next commit, files stay on disk
+Paths in HEAD get their committed version back in the index (git reset),
+so the commit does not delete them; new files are removed from the index
+*/
+func UntrackPaths(paths []string) (Result, error) {
+	return journaled("untrack", func() (Result, error) { return untrackPaths(paths) })
+}
+
+func untrackPaths(paths []string) (Result, error) {
+	res := newResult("untrack")
+
+	committed := pathsInHead(paths)
+	var reset, added []string
+	for _, p := range paths {
+		if committed[p] {
+			reset = append(reset, p)
+		} else {
+			added = append(added, p)
+		}
+	}
+
+	if len(reset) > 0 {
+		if err := res.git(append([]string{"reset", "-q", "--"}, reset...)...); err != nil {
+			return res, err
+		}
+		res.warn(fmt.Sprintf("%d file(s) stay in the repository at their last committed version", len(reset)))
+	}
+	if len(added) > 0 {
+		args := append([]string{"rm", "--cached", "-q", "--ignore-unmatch", "--"}, added...)
+		if err := res.git(args...); err != nil {
+			return res, err
+		}
+	}
+
+	res.addRef(paths...)
+	res.Summary = fmt.Sprintf("Left %d file(s) out of the commit (kept on disk)", len(paths))
+	return res, nil
+}
+
+// pathsInHead reports which paths exist in the HEAD commit
+func pathsInHead(paths []string) map[string]bool {
+	in := map[string]bool{}
+	if !hasAnyCommit() {
+		return in
+	}
+
+	out, _ := system.GitRaw(append([]string{"ls-tree", "-r", "--name-only", "-z", "HEAD", "--"}, paths...)...)
+	for _, p := range strings.Split(out, "\x00") {
+		if p != "" {
+			in[p] = true
+		}
+	}
+	return in
+}
+
+// IgnorePaths appends paths to .gitignore, leaves them out of the commit and stages .gitignore
+func IgnorePaths(paths []string) (Result, error) {
+	return journaled("ignore", func() (Result, error) { return ignorePaths(paths) })
+}
+
+func ignorePaths(paths []string) (Result, error) {
+	res := newResult("ignore")
+	file := filepath.Join(config.Load().GetWorkDir(), ".gitignore")
+
+	existing, _ := os.ReadFile(file)
+	present := map[string]bool{}
+	for _, l := range strings.Split(string(existing), "\n") {
+		present[strings.TrimSpace(l)] = true
+	}
+
+	var add strings.Builder
+	if len(existing) > 0 && !strings.HasSuffix(string(existing), "\n") {
+		add.WriteString("\n")
+	}
+	for _, p := range paths {
+		if entry := "/" + filepath.ToSlash(p); !present[entry] {
+			add.WriteString(entry + "\n")
+		}
+	}
+
+	f, err := os.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return res, err
+	}
+	_, err = f.WriteString(add.String())
+	f.Close()
+	if err != nil {
+		return res, err
+	}
+
+	untracked, err := untrackPaths(paths)
+	if err != nil {
+		return res, err
+	}
+	if len(untracked.Warnings) > 0 {
+		// .gitignore only affects files git does not track yet
+		res.warn("Files already in the repository stay tracked; remove them with git rm --cached to stop tracking")
+	}
+	if err := res.git("add", "--", ".gitignore"); err != nil {
+		return res, err
+	}
+
+	res.addRef(paths...)
+	res.Summary = fmt.Sprintf("Added %d file(s) to .gitignore", len(paths))
+	return res, nil
+}
+
+// LFSAvailable reports whether the git-lfs extension is installed
+func LFSAvailable() bool {
+	return system.GitOK("lfs", "version")
+}
+
+/*
+LFSTrack tracks paths with Git LFS (.gitattributes is created if needed)
+and re-stages them so the index holds LFS pointers
+*/
+func LFSTrack(paths []string) (Result, error) {
+	return journaled("lfs-track", func() (Result, error) { return lfsTrack(paths) })
+}
+
+func lfsTrack(paths []string) (Result, error) {
+	res := newResult("lfs-track")
+
+	if !LFSAvailable() {
+		return res, ErrLFSMissing
+	}
+
+	if err := res.git("lfs", "install", "--local"); err != nil {
+		return res, err
+	}
+
+	args := append([]string{"lfs", "track", "--"}, paths...)
+	if err := res.git(args...); err != nil {
+		return res, err
+	}
+
+	args = append([]string{"add", "--", ".gitattributes"}, paths...)
+	if err := res.git(args...); err != nil {
+		return res, err
+	}
+
+	res.addRef(paths...)
+	res.Summary = fmt.Sprintf("Tracking %d file(s) with Git LFS", len(paths))
+	return res, nil
+}
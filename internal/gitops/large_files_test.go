@@ -0,0 +1,45 @@
+package gitops
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUntrackPaths(t *testing.T) {
+	dir, git := tempRepo(t)
+	write := func(name, text string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(text), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("data.bin", "v1\n")
+	git("add", "data.bin")
+	git("commit", "-q", "-m", "data")
+
+	write("data.bin", "v2, now huge\n")
+	write("dump.sql", "new\n")
+	git("add", "data.bin", "dump.sql")
+
+	res, err := UntrackPaths([]string{"data.bin", "dump.sql"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// committed file keeps its HEAD version in the index (no deletion staged)
+	status := git("status", "--porcelain")
+	for _, want := range []string{" M data.bin", "?? dump.sql"} {
+		if !strings.Contains(status, want+"\n") {
+			t.Errorf("status missing %q:\n%s", want, status)
+		}
+	}
+	if git("diff", "--cached", "--name-only") != "" {
+		t.Errorf("index still differs from HEAD:\n%s", git("diff", "--cached", "--stat"))
+	}
+	if len(res.Warnings) != 1 {
+		t.Errorf("Warnings = %q, want one about the committed file", res.Warnings)
+	}
+}
@@ -0,0 +1,133 @@
+package gitops
+
+import (
+	"strings"
+
+	"git-genius/internal/system"
+)
+
+/* ============================================================
+   MERGE
+   ============================================================ */
+
+/*
+Merge merges branch (local or remote-tracking) into the current branch
+Requires a clean work tree; conflicts return ErrConflict and leave the
+merge in progress for the conflict assistant (CompleteMerge / AbortMerge)
+*/
+func Merge(branch string, noFF bool) (Result, error) {
+	return journaled("merge", func() (Result, error) { return merge(branch, noFF) })
+}
+
+func merge(branch string, noFF bool) (Result, error) {
+	res := newResult("merge")
+
+	if !system.IsGitRepo() {
+		return res, ErrNotRepo
+	}
+	if !hasAnyCommit() {
+		return res, ErrNoCommits
+	}
+
+	branch = strings.TrimSpace(branch)
+	if branch == "" || !(refExists("refs/heads/"+branch) || refExists("refs/remotes/"+branch)) {
+		return res, ErrInvalidArguments
+	}
+
+	current := CurrentBranch()
+	if branch == current {
+		return res, ErrInvalidArguments
+	}
+	if err := checkNoPending(); err != nil {
+		return res, err
+	}
+	if isWorkingTreeDirty() {
+		return res, ErrDirtyWorkTree
+	}
+
+	res.addRef(current, branch)
+
+	args := []string{"merge", "--no-edit"}
+	if noFF {
+		args = append(args, "--no-ff")
+	}
+	args = append(args, branch)
+
+	if err := res.git(args...); err != nil {
+		return res, err
+	}
+
+	if strings.Contains(res.Output, "Already up to date") {
+		res.Summary = current + " already contains " + branch
+	} else {
+		res.Summary = "Merged " + branch + " into " + current
+	}
+	return res, nil
+}
+
+/* ============================================================
+   MERGE STATE
+   ============================================================ */
+
+// ConflictedPaths lists files with unresolved conflicts
+func ConflictedPaths() []string {
+	st, err := ReadStatus()
+	if err != nil {
+		return nil
+	}
+
+	paths := make([]string, 0, len(st.Conflicted))
+	for _, e := range st.Conflicted {
+		paths = append(paths, e.Path)
+	}
+	return paths
+}
+
+/*
+CompleteMerge finishes once every conflict is resolved
+Merge / rebase / cherry-pick / revert: ContinuePending
+Stash pop / other conflicts: resolutions are unstaged again (no commit),
+the stash entry is kept for the user to drop
+*/
+func CompleteMerge() (Result, error) {
+	res := newResult("merge-complete")
+
+	if left := ConflictedPaths(); len(left) > 0 {
+		res.addRef(left...)
+		return res, ErrConflict
+	}
+
+	if _, ok := PendingOperation(); ok {
+		return ContinuePending()
+	}
+
+	if err := res.git("reset", "-q"); err != nil {
+		return res, err
+	}
+	res.warn("Stash entries are kept: drop them once you are happy with the result")
+	res.Summary = "Conflicts resolved (changes left uncommitted)"
+	return res, nil
+}
+
+// AbortMerge returns to the state before the merge / rebase… (or conflicted stash pop)
+func AbortMerge() (Result, error) {
+	if _, ok := PendingOperation(); ok {
+		return AbortPending()
+	}
+	return journaled("merge-abort", abortMerge)
+}
+
+func abortMerge() (Result, error) {
+	res := newResult("merge-abort")
+
+	if len(ConflictedPaths()) == 0 {
+		return res, ErrNothingToUndo
+	}
+
+	if err := res.git("reset", "--merge"); err != nil {
+		return res, err
+	}
+	res.warn("Stash entries are kept: nothing was lost")
+	res.Summary = "Conflicted changes rolled back"
+	return res, nil
+}
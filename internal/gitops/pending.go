@@ -0,0 +1,240 @@
+package gitops
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"git-genius/internal/system"
+)
+
+/* ============================================================
+   IN-PROGRESS OPERATIONS (merge, rebase, cherry-pick, revert, bisect)
+   ============================================================ */
+
+// Pending operation kinds
+const (
+	PendingMerge      = "merge"
+	PendingRebase     = "rebase"
+	PendingAm         = "am"
+	PendingCherryPick = "cherry-pick"
+	PendingRevert     = "revert"
+	PendingBisect     = "bisect"
+)
+
+// Pending describes an unfinished git operation
+type Pending struct {
+	Kind   string `json:"kind"`
+	Detail string `json:"detail,omitempty"` // "step 2/5 onto main", commit subject…
+}
+
+// CanSkip reports whether "skip" applies to this kind
+func (p Pending) CanSkip() bool {
+	return p.Kind != PendingMerge
+}
+
+/*
+PendingOperation inspects .git for unfinished operations
+(MERGE_HEAD, rebase-merge/, rebase-apply/, CHERRY_PICK_HEAD, REVERT_HEAD, BISECT_LOG)
+*/
+func PendingOperation() (Pending, bool) {
+	if !system.IsGitRepo() {
+		return Pending{}, false
+	}
+
+	dir := system.GitDir()
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(dir, name))
+		return err == nil
+	}
+	read := func(name string) string {
+		data, _ := os.ReadFile(filepath.Join(dir, name))
+		return strings.TrimSpace(string(data))
+	}
+
+	switch {
+	case exists("rebase-merge"):
+		return Pending{Kind: PendingRebase, Detail: rebaseDetail(read("rebase-merge/msgnum"),
+			read("rebase-merge/end"), read("rebase-merge/head-name"))}, true
+	case exists("rebase-apply/applying"):
+		return Pending{Kind: PendingAm, Detail: "patch " + read("rebase-apply/next") + "/" + read("rebase-apply/last")}, true
+	case exists("rebase-apply"):
+		return Pending{Kind: PendingRebase, Detail: rebaseDetail(read("rebase-apply/next"),
+			read("rebase-apply/last"), read("rebase-apply/head-name"))}, true
+	case exists("MERGE_HEAD"):
+		return Pending{Kind: PendingMerge, Detail: firstLine(read("MERGE_MSG"))}, true
+	case exists("CHERRY_PICK_HEAD"):
+		return Pending{Kind: PendingCherryPick, Detail: commitLine(read("CHERRY_PICK_HEAD"))}, true
+	case exists("REVERT_HEAD"):
+		return Pending{Kind: PendingRevert, Detail: commitLine(read("REVERT_HEAD"))}, true
+	case exists("BISECT_LOG"):
+		return Pending{Kind: PendingBisect, Detail: bisectDetail()}, true
+	}
+	return Pending{}, false
+}
+
+func rebaseDetail(step, total, head string) string {
+	d := "step " + step + "/" + total
+	if b := strings.TrimPrefix(head, "refs/heads/"); b != "" && b != "detached HEAD" {
+		d += " rebasing " + b
+	}
+	return d
+}
+
+func commitLine(hash string) string {
+	out, err := system.GitRaw("log", "-1", "--format=%h %s", hash)
+	if err != nil {
+		return shortHash(hash)
+	}
+	return strings.TrimSpace(out)
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+func bisectDetail() string {
+	out, err := system.GitRaw("bisect", "visualize", "--oneline")
+	if err != nil {
+		return ""
+	}
+	n := len(splitLines(out))
+	return fmt.Sprintf("%d commit(s) left to test", n)
+}
+
+// checkNoPending stops operations that would fail mid-merge / mid-rebase
+func checkNoPending() error {
+	if p, ok := PendingOperation(); ok {
+		return fmt.Errorf("%w: %s", ErrOperationPending, p.Kind)
+	}
+	return nil
+}
+
+/* ============================================================
+   CONTINUE / SKIP / ABORT
+   ============================================================ */
+
+// noEditor keeps git from opening an editor for prepared messages
+var noEditor = []string{"GIT_EDITOR=true"}
+
+// ContinuePending continues the pending operation (conflicts must be resolved)
+func ContinuePending() (Result, error) {
+	return journaled("continue", continuePending)
+}
+
+func continuePending() (Result, error) {
+	res := newResult("continue")
+
+	p, ok := PendingOperation()
+	if !ok {
+		return res, ErrNothingPending
+	}
+	res.Operation = p.Kind + "-continue"
+
+	if left := ConflictedPaths(); len(left) > 0 {
+		res.addRef(left...)
+		return res, ErrConflict
+	}
+
+	var args []string
+	switch p.Kind {
+	case PendingMerge:
+		args = []string{"commit", "--no-edit"}
+	case PendingRebase, PendingCherryPick, PendingRevert:
+		args = []string{p.Kind, "--continue"}
+	case PendingAm:
+		args = []string{"am", "--continue"}
+	default:
+		return res, ErrInvalidArguments // bisect: use BisectMark
+	}
+
+	if err := res.gitEnv(noEditor, args...); err != nil {
+		return res, err
+	}
+
+	res.Summary = pendingSummary(p.Kind, "continued")
+	return res, nil
+}
+
+// SkipPending skips the current commit / patch (rebase, am, cherry-pick, revert, bisect)
+func SkipPending() (Result, error) {
+	return journaled("skip", skipPending)
+}
+
+func skipPending() (Result, error) {
+	res := newResult("skip")
+
+	p, ok := PendingOperation()
+	if !ok {
+		return res, ErrNothingPending
+	}
+	res.Operation = p.Kind + "-skip"
+	if !p.CanSkip() {
+		return res, ErrInvalidArguments
+	}
+
+	args := []string{p.Kind, "--skip"}
+	if p.Kind == PendingBisect {
+		args = []string{"bisect", "skip"}
+	}
+
+	if err := res.gitEnv(noEditor, args...); err != nil {
+		return res, err
+	}
+
+	res.Summary = pendingSummary(p.Kind, "skipped one commit")
+	return res, nil
+}
+
+// AbortPending returns to the state before the pending operation started
+func AbortPending() (Result, error) {
+	return journaled("abort", abortPending)
+}
+
+func abortPending() (Result, error) {
+	res := newResult("abort")
+
+	p, ok := PendingOperation()
+	if !ok {
+		return res, ErrNothingPending
+	}
+	res.Operation = p.Kind + "-abort"
+
+	args := []string{p.Kind, "--abort"}
+	if p.Kind == PendingBisect {
+		args = []string{"bisect", "reset"}
+	}
+
+	if err := res.git(args...); err != nil {
+		return res, err
+	}
+
+	res.Summary = pendingSummary(p.Kind, "aborted")
+	return res, nil
+}
+
+// BisectMark marks the checked out commit good or bad
+func BisectMark(good bool) (Result, error) {
+	res := newResult("bisect-mark")
+
+	term := "bad"
+	if good {
+		term = "good"
+	}
+
+	if err := res.git("bisect", term); err != nil {
+		return res, err
+	}
+
+	res.Summary = "Marked " + term
+	if strings.Contains(res.Output, "is the first bad commit") {
+		res.Summary = "Found the first bad commit (Abort = finish bisect)"
+	}
+	return res, nil
+}
+
+func pendingSummary(kind, action string) string {
+	return strings.ToUpper(kind[:1]) + kind[1:] + " " + action
+}
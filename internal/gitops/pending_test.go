@@ -0,0 +1,78 @@
+package gitops
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPendingOperation(t *testing.T) {
+	tests := []struct {
+		name   string
+		files  map[string]string // path in .git → content
+		kind   string
+		detail string
+	}{
+		{"nothing pending", nil, "", ""},
+		{"merge", map[string]string{
+			"MERGE_HEAD": "<head>\n",
+			"MERGE_MSG":  "Merge branch 'topic'\n\n# Conflicts:\n",
+		}, PendingMerge, "Merge branch 'topic'"},
+		{"interactive rebase", map[string]string{
+			"rebase-merge/msgnum":    "2\n",
+			"rebase-merge/end":       "5\n",
+			"rebase-merge/head-name": "refs/heads/topic\n",
+		}, PendingRebase, "step 2/5 rebasing topic"},
+		{"rebase on a detached HEAD", map[string]string{
+			"rebase-apply/next":      "1\n",
+			"rebase-apply/last":      "3\n",
+			"rebase-apply/head-name": "detached HEAD\n",
+		}, PendingRebase, "step 1/3"},
+		{"am", map[string]string{
+			"rebase-apply/applying": "",
+			"rebase-apply/next":     "2\n",
+			"rebase-apply/last":     "4\n",
+		}, PendingAm, "patch 2/4"},
+		{"cherry-pick", map[string]string{"CHERRY_PICK_HEAD": "<head>\n"}, PendingCherryPick, "<short> init"},
+		{"revert", map[string]string{"REVERT_HEAD": "<head>\n"}, PendingRevert, "<short> init"},
+		{"bisect", map[string]string{"BISECT_LOG": "git bisect start\n"}, PendingBisect, ""},
+		{"rebase wins over a merge", map[string]string{
+			"MERGE_HEAD":             "<head>\n",
+			"rebase-merge/msgnum":    "1\n",
+			"rebase-merge/end":       "1\n",
+			"rebase-merge/head-name": "refs/heads/main\n",
+		}, PendingRebase, "step 1/1 rebasing main"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, git := journalRepo(t)
+			head := strings.TrimSpace(git("rev-parse", "HEAD"))
+			short := strings.TrimSpace(git("rev-parse", "--short", "HEAD"))
+			gitDir := strings.TrimSpace(git("rev-parse", "--absolute-git-dir"))
+
+			for name, content := range tt.files {
+				path := filepath.Join(gitDir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				content = strings.ReplaceAll(content, "<head>", head)
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			p, ok := PendingOperation()
+			if ok != (tt.kind != "") || p.Kind != tt.kind {
+				t.Fatalf("PendingOperation() = %+v, %v; want kind %q", p, ok, tt.kind)
+			}
+			if tt.kind == PendingBisect {
+				return // detail comes from git bisect itself
+			}
+			if want := strings.ReplaceAll(tt.detail, "<short>", short); p.Detail != want {
+				t.Errorf("Detail = %q, want %q", p.Detail, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package gitops
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"git-genius/internal/config"
+	"git-genius/internal/system"
+)
+
+/* ============================================================
+   COMMIT CLEANUP (git rebase -i without the todo syntax)
+   ============================================================
+
+   git-genius writes the todo list itself and hands it to git
+   through GIT_SEQUENCE_EDITOR; rewording uses exec + commit --amend,
+   so no editor ever opens. Failure = rebase --abort.
+*/
+
+// Rebase todo actions
+const (
+	ActionPick   = "pick"
+	ActionReword = "reword"
+	ActionSquash = "squash" // meld into previous, keep both messages
+	ActionFixup  = "fixup"  // meld into previous, drop this message
+	ActionDrop   = "drop"
+)
+
+// TodoItem is one commit in the cleanup list (oldest first)
+type TodoItem struct {
+	Hash    string    `json:"hash"`
+	Subject string    `json:"subject"`
+	Author  string    `json:"author"`
+	When    time.Time `json:"when"`
+	Action  string    `json:"action"`
+	Message string    `json:"message,omitempty"` // new message for reword
+}
+
+/*
+UnpushedCommits lists commits not on the upstream (or on no remote
+branch when there is none), oldest first, ready for RewriteCommits
+*/
+func UnpushedCommits() ([]TodoItem, error) {
+	if !hasAnyCommit() {
+		return nil, ErrNoCommits
+	}
+
+	rng := unpushedRange()
+
+	if merges, _ := system.GitRaw(append([]string{"rev-list", "--merges"}, rng...)...); merges != "" {
+		return nil, fmt.Errorf("%w: unpushed commits contain merges", ErrInvalidArguments)
+	}
+
+	args := append([]string{"log", "--reverse", "--format=%H%x1f%s%x1f%an%x1f%ct"}, rng...)
+	out, err := system.GitRaw(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []TodoItem
+	for _, l := range strings.Split(out, "\n") {
+		f := strings.Split(l, fieldSep)
+		if len(f) != 4 {
+			continue
+		}
+		items = append(items, TodoItem{
+			Hash:    f[0],
+			Subject: f[1],
+			Author:  f[2],
+			When:    unixTime(f[3]),
+			Action:  ActionPick,
+		})
+	}
+	return items, nil
+}
+
+// unpushedRange is the rev-list range of local-only commits
+func unpushedRange() []string {
+	if system.GitOK("rev-parse", "--verify", "--quiet", "@{upstream}") {
+		return []string{"@{upstream}..HEAD"}
+	}
+	return outgoingArgs(config.Load().Remote, []string{"HEAD"})
+}
+
+/*
+RewriteCommits applies items (UnpushedCommits order, possibly reordered)
+A backup ref is created first; any failure aborts the rebase
+*/
+func RewriteCommits(items []TodoItem) (Result, error) {
+	return journaled("rewrite-commits", func() (Result, error) { return rewriteCommits(items) })
+}
+
+func rewriteCommits(items []TodoItem) (Result, error) {
+	res := newResult("rewrite-commits")
+
+	if !system.IsGitRepo() {
+		return res, ErrNotRepo
+	}
+	if err := checkNoPending(); err != nil {
+		return res, err
+	}
+	if isWorkingTreeDirty() {
+		return res, ErrDirtyWorkTree
+	}
+	if err := validateTodo(items); err != nil {
+		return res, err
+	}
+
+	current, err := UnpushedCommits()
+	if err != nil {
+		return res, err
+	}
+	if !sameCommits(current, items) {
+		return res, ErrStateChanged
+	}
+
+	tmp, err := os.MkdirTemp("", "git-genius-rebase-")
+	if err != nil {
+		return res, err
+	}
+	defer os.RemoveAll(tmp)
+
+	todo, err := buildTodo(items, tmp)
+	if err != nil {
+		return res, err
+	}
+	todoFile := filepath.Join(tmp, "todo")
+	if err := os.WriteFile(todoFile, []byte(todo), 0600); err != nil {
+		return res, err
+	}
+
+	backup, err := CreateBackup(res.Operation)
+	if err != nil {
+		return res, err
+	}
+	res.addRef(CurrentBranch(), backup)
+
+	args := []string{"rebase", "-i", "--quiet"}
+	if parent := rebaseBase(current[0].Hash); parent != "" {
+		args = append(args, parent)
+	} else {
+		args = append(args, "--root")
+	}
+
+	env := []string{
+		"GIT_SEQUENCE_EDITOR=cp " + shellQuote(todoFile),
+		"GIT_EDITOR=true", // squash: keep the combined message
+	}
+
+	if err := res.gitEnv(env, args...); err != nil {
+		reason := "git rebase failed"
+		if errors.Is(err, ErrConflict) {
+			reason = "commits conflict in the new order"
+		}
+		res.Output = "" // git's "run rebase --continue" hints no longer apply
+		if p, ok := PendingOperation(); ok && p.Kind == PendingRebase {
+			_ = res.git("rebase", "--abort")
+		}
+		res.warn("Nothing changed (backup: " + backup + ")")
+		return res, fmt.Errorf("%w: %s", ErrRewriteAborted, reason)
+	}
+
+	res.Summary = fmt.Sprintf("Rewrote %d commit(s) (backup: %s)", len(items), backup)
+	return res, nil
+}
+
+// validateTodo checks actions and that squash / fixup have a target
+func validateTodo(items []TodoItem) error {
+	if len(items) == 0 {
+		return ErrNoCommits
+	}
+
+	kept := false
+	for _, it := range items {
+		switch it.Action {
+		case ActionPick, ActionDrop:
+		case ActionReword:
+			if strings.TrimSpace(it.Message) == "" {
+				return fmt.Errorf("%w: reword %s needs a message", ErrInvalidArguments, shortHash(it.Hash))
+			}
+		case ActionSquash, ActionFixup:
+			if !kept {
+				return fmt.Errorf("%w: %s %s has no earlier commit to join", ErrInvalidArguments, it.Action, shortHash(it.Hash))
+			}
+		default:
+			return fmt.Errorf("%w: unknown action %q", ErrInvalidArguments, it.Action)
+		}
+		if it.Action != ActionDrop {
+			kept = true
+		}
+	}
+
+	if !kept {
+		return fmt.Errorf("%w: every commit would be dropped (use Undo last commit instead)", ErrInvalidArguments)
+	}
+	return nil
+}
+
+// sameCommits makes sure items still describe the unpushed commits
+func sameCommits(current, items []TodoItem) bool {
+	if len(current) != len(items) {
+		return false
+	}
+	seen := map[string]bool{}
+	for _, c := range current {
+		seen[c.Hash] = true
+	}
+	for _, it := range items {
+		if !seen[it.Hash] {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+buildTodo renders the todo list; rewords become
+pick + exec "git commit --amend -F <file>"
+*/
+func buildTodo(items []TodoItem, dir string) (string, error) {
+	var b strings.Builder
+
+	for i, it := range items {
+		action := it.Action
+		if action == ActionReword {
+			action = ActionPick
+		}
+		fmt.Fprintf(&b, "%s %s %s\n", action, it.Hash, it.Subject)
+
+		if it.Action != ActionReword {
+			continue
+		}
+
+		msgFile := filepath.Join(dir, "msg-"+strconv.Itoa(i))
+		if err := os.WriteFile(msgFile, []byte(it.Message+"\n"), 0600); err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "exec git commit --amend --allow-empty --no-verify --quiet -F %s\n", shellQuote(msgFile))
+	}
+	return b.String(), nil
+}
+
+// rebaseBase is the parent of the oldest commit ("" = root commit)
+func rebaseBase(oldest string) string {
+	out, err := system.GitRaw("log", "-1", "--format=%P", oldest)
+	if err != nil {
+		return ""
+	}
+	parents := strings.Fields(out)
+	if len(parents) == 0 {
+		return ""
+	}
+	return parents[0]
+}
+
+// shellQuote quotes a path for the sh command line git uses for editors
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,114 @@
+package gitops
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git-genius/internal/system"
+)
+
+func TestValidateTodo(t *testing.T) {
+	item := func(hash, action, msg string) TodoItem {
+		return TodoItem{Hash: hash, Subject: "subject " + hash, Action: action, Message: msg}
+	}
+
+	tests := []struct {
+		name  string
+		items []TodoItem
+		want  error // nil = valid
+		text  string
+	}{
+		{"empty list", nil, ErrNoCommits, ""},
+		{"pick and drop", []TodoItem{item("a1", ActionPick, ""), item("b2", ActionDrop, "")}, nil, ""},
+		{"squash and fixup", []TodoItem{item("a1", ActionPick, ""), item("b2", ActionSquash, ""), item("c3", ActionFixup, "")}, nil, ""},
+		{"reword with message", []TodoItem{item("a1", ActionReword, "feat: better")}, nil, ""},
+		{"reword without message", []TodoItem{item("a1", ActionReword, "  ")}, ErrInvalidArguments, "reword a1 needs a message"},
+		{"squash first", []TodoItem{item("a1", ActionSquash, ""), item("b2", ActionPick, "")}, ErrInvalidArguments, "squash a1 has no earlier commit"},
+		{"fixup after dropped", []TodoItem{item("a1", ActionDrop, ""), item("b2", ActionFixup, "")}, ErrInvalidArguments, "fixup b2 has no earlier commit"},
+		{"unknown action", []TodoItem{item("a1", "edit", "")}, ErrInvalidArguments, `unknown action "edit"`},
+		{"everything dropped", []TodoItem{item("a1", ActionDrop, ""), item("b2", ActionDrop, "")}, ErrInvalidArguments, "every commit would be dropped"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTodo(tt.items)
+			if !errors.Is(err, tt.want) || (tt.want == nil) != (err == nil) {
+				t.Fatalf("validateTodo() error = %v, want %v", err, tt.want)
+			}
+			if err != nil && !strings.Contains(err.Error(), tt.text) {
+				t.Errorf("error %q does not mention %q", err, tt.text)
+			}
+		})
+	}
+}
+
+func TestBuildTodo(t *testing.T) {
+	dir := t.TempDir()
+	items := []TodoItem{
+		{Hash: "a1", Subject: "first", Action: ActionPick},
+		{Hash: "b2", Subject: "second", Action: ActionReword, Message: "feat: second, reworded"},
+		{Hash: "c3", Subject: "third", Action: ActionFixup},
+		{Hash: "d4", Subject: "fourth", Action: ActionDrop},
+	}
+
+	got, err := buildTodo(items, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	msgFile := filepath.Join(dir, "msg-1")
+	want := "pick a1 first\n" +
+		"pick b2 second\n" +
+		"exec git commit --amend --allow-empty --no-verify --quiet -F " + shellQuote(msgFile) + "\n" +
+		"fixup c3 third\n" +
+		"drop d4 fourth\n"
+	if got != want {
+		t.Errorf("buildTodo()\n got %q\nwant %q", got, want)
+	}
+
+	msg, err := os.ReadFile(msgFile)
+	if err != nil || string(msg) != "feat: second, reworded\n" {
+		t.Errorf("message file = %q, %v", msg, err)
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	if got, want := shellQuote("/tmp/it's here"), `'/tmp/it'\''s here'`; got != want {
+		t.Errorf("shellQuote() = %s, want %s", got, want)
+	}
+}
+
+func TestUnpushedCommits(t *testing.T) {
+	fake := useFake(t)
+	fake.On("log --reverse --format=%H%x1f%s%x1f%an%x1f%ct @{upstream}..HEAD", system.Response{
+		Stdout: "a1\x1ffirst\x1fAda\x1f1700000000\nb2\x1fsecond\x1fAda\x1f1700000060\n",
+	})
+
+	items, err := UnpushedCommits()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 || items[0].Hash != "a1" || items[1].Subject != "second" {
+		t.Fatalf("UnpushedCommits() = %+v", items)
+	}
+	for _, it := range items {
+		if it.Action != ActionPick {
+			t.Errorf("%s action = %q, want pick", it.Hash, it.Action)
+		}
+	}
+	if items[1].When.Unix() != 1700000060 {
+		t.Errorf("When = %v", items[1].When)
+	}
+}
+
+func TestUnpushedCommitsRefusesMerges(t *testing.T) {
+	fake := useFake(t)
+	fake.On("rev-list --merges @{upstream}..HEAD", system.Response{Stdout: "m1\n"})
+
+	if _, err := UnpushedCommits(); !errors.Is(err, ErrInvalidArguments) {
+		t.Errorf("UnpushedCommits() error = %v, want ErrInvalidArguments", err)
+	}
+}
@@ -0,0 +1,380 @@
+package gitops
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"git-genius/internal/system"
+)
+
+/* ============================================================
+   RECOVERY (reflog, deleted branches, dropped stashes)
+   ============================================================
+
+   Everything that moves HEAD first saves it under
+   refs/genius/backup/<unix time>-<operation>, so a recovery can be undone too.
+*/
+
+// BackupPrefix is where backup refs live
+const BackupPrefix = "refs/genius/backup/"
+
+// ReflogEntry is one HEAD movement
+type ReflogEntry struct {
+	Selector string    `json:"selector"` // HEAD@{n}
+	Hash     string    `json:"hash"`
+	Action   string    `json:"action"` // commit, checkout, reset, rebase, pull, merge…
+	Message  string    `json:"message"`
+	When     time.Time `json:"when"`
+}
+
+// DeletedBranch is a branch that only survives in the reflog
+type DeletedBranch struct {
+	Name string    `json:"name"`
+	Hash string    `json:"hash"`
+	When time.Time `json:"when"` // last time it was left
+}
+
+// DroppedStash is an unreachable stash commit
+type DroppedStash struct {
+	Hash    string    `json:"hash"`
+	Message string    `json:"message"`
+	When    time.Time `json:"when"`
+}
+
+// Backup is a saved position of HEAD
+type Backup struct {
+	Ref       string    `json:"ref"`
+	Hash      string    `json:"hash"`
+	When      time.Time `json:"when"`                // when the backup was made
+	Operation string    `json:"operation,omitempty"` // what moved HEAD afterwards
+}
+
+// fieldSep separates --format fields (never appears in messages)
+const fieldSep = "\x1f"
+
+/*
+Reflog returns the latest HEAD movements, newest first
+limit <= 0 means everything
+*/
+func Reflog(limit int) (Result, error) {
+	res := newResult("reflog")
+
+	if !system.IsGitRepo() {
+		return res, ErrNotRepo
+	}
+	if !hasAnyCommit() {
+		return res, ErrNoCommits
+	}
+
+	entries, err := readReflog(limit)
+	if err != nil {
+		return res, err
+	}
+
+	res.Data = entries
+	res.Summary = fmt.Sprintf("%d reflog entries", len(entries))
+	return res, nil
+}
+
+func readReflog(limit int) ([]ReflogEntry, error) {
+	args := []string{"reflog", "show", "--format=%gd%x1f%H%x1f%gs%x1f%ct"}
+	if limit > 0 {
+		args = append(args, "-n", strconv.Itoa(limit))
+	}
+	args = append(args, "HEAD")
+
+	out, err := system.GitRaw(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []ReflogEntry
+	for _, l := range strings.Split(out, "\n") {
+		f := strings.Split(l, fieldSep)
+		if len(f) != 4 {
+			continue
+		}
+		action, msg := splitReflogSubject(f[2])
+		entries = append(entries, ReflogEntry{
+			Selector: f[0],
+			Hash:     f[1],
+			Action:   action,
+			Message:  msg,
+			When:     unixTime(f[3]),
+		})
+	}
+	return entries, nil
+}
+
+/*
+splitReflogSubject turns "rebase (finish): returning to refs/heads/main"
+into ("rebase", "finish: returning to refs/heads/main")
+*/
+func splitReflogSubject(s string) (string, string) {
+	head, msg, ok := strings.Cut(s, ": ")
+	if !ok {
+		return "other", s
+	}
+
+	action := head
+	if i := strings.IndexAny(head, " ("); i > 0 {
+		action = head[:i]
+		detail := strings.Trim(head[i:], " ()")
+		if detail != "" {
+			msg = detail + ": " + msg
+		}
+	}
+	return action, msg
+}
+
+/* ============================================================
+   BACKUP REFS
+   ============================================================ */
+
+/*
+CreateBackup saves HEAD under refs/genius/backup/<unix time>-<op>
+The time is part of the name: the ref itself has no date of its own
+(creatordate would be the date of the commit it points to)
+*/
+func CreateBackup(op string) (string, error) {
+	return createBackupAt(op, "HEAD")
+}
+
+// createBackupAt saves rev the way CreateBackup saves HEAD
+func createBackupAt(op, rev string) (string, error) {
+	now := time.Now()
+	ref := BackupPrefix + strconv.FormatInt(now.Unix(), 10) + "-" + op
+	if system.GitOK("show-ref", "--verify", "--quiet", ref) {
+		ref = fmt.Sprintf("%s%d.%09d-%s", BackupPrefix, now.Unix(), now.Nanosecond(), op)
+	}
+
+	if _, err := system.GitCombined("update-ref", "-m", "git-genius backup ("+op+")", ref, rev); err != nil {
+		return "", err
+	}
+	return ref, nil
+}
+
+// Backups lists backup refs, newest first
+func Backups() ([]Backup, error) {
+	out, err := system.GitRaw("for-each-ref", "--format=%(refname)%1f%(objectname)", BackupPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []Backup
+	for _, l := range strings.Split(out, "\n") {
+		f := strings.Split(l, fieldSep)
+		if len(f) != 2 {
+			continue
+		}
+		b := Backup{Ref: f[0], Hash: f[1]}
+		b.When, b.Operation = parseBackupName(strings.TrimPrefix(f[0], BackupPrefix))
+		backups = append(backups, b)
+	}
+
+	sort.SliceStable(backups, func(i, j int) bool { return backups[i].When.After(backups[j].When) })
+	return backups, nil
+}
+
+// parseBackupName reads "<unix>[.<nanos>]-<op>"
+func parseBackupName(name string) (time.Time, string) {
+	stamp, op, _ := strings.Cut(name, "-")
+	secs, nanos, _ := strings.Cut(stamp, ".")
+	sec, err := strconv.ParseInt(secs, 10, 64)
+	if err != nil {
+		return time.Time{}, op
+	}
+	nsec, _ := strconv.ParseInt(nanos, 10, 64)
+	return time.Unix(sec, nsec), op
+}
+
+/* ============================================================
+   RESTORE ACTIONS
+   ============================================================ */
+
+/*
+RestoreHead moves the current branch (or detached HEAD) to hash
+Uses reset --keep: uncommitted changes survive, git refuses
+when they would be overwritten
+*/
+func RestoreHead(hash string) (Result, error) {
+	return journaled("restore-head", func() (Result, error) { return restoreHead(hash) })
+}
+
+func restoreHead(hash string) (Result, error) {
+	res := newResult("restore-head")
+
+	if hash == "" {
+		return res, ErrInvalidArguments
+	}
+
+	backup, err := CreateBackup(res.Operation)
+	if err != nil {
+		return res, err
+	}
+	res.addRef(CurrentBranch(), backup)
+
+	if err := res.git("reset", "--keep", hash); err != nil {
+		return res, err
+	}
+
+	res.Summary = "HEAD restored to " + shortHash(hash) + " (backup: " + backup + ")"
+	return res, nil
+}
+
+/*
+DeletedBranches finds branches that were checked out earlier
+but no longer exist. The tip is HEAD just before leaving the branch.
+*/
+func DeletedBranches() ([]DeletedBranch, error) {
+	entries, err := readReflog(0)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	var branches []DeletedBranch
+
+	for i, e := range entries {
+		if e.Action != "checkout" || i+1 >= len(entries) {
+			continue
+		}
+
+		// "moving from <old> to <new>"
+		rest, ok := strings.CutPrefix(e.Message, "moving from ")
+		if !ok {
+			continue
+		}
+		name, _, _ := strings.Cut(rest, " to ")
+
+		if name == "" || seen[name] || !isBranchName(name) {
+			continue
+		}
+		seen[name] = true
+
+		if system.GitOK("show-ref", "--verify", "--quiet", "refs/heads/"+name) {
+			continue
+		}
+
+		branches = append(branches, DeletedBranch{
+			Name: name,
+			Hash: entries[i+1].Hash,
+			When: e.When,
+		})
+	}
+	return branches, nil
+}
+
+// isBranchName skips hashes (detached HEAD) in checkout messages
+func isBranchName(name string) bool {
+	if len(name) == 40 && strings.Trim(name, "0123456789abcdef") == "" {
+		return false
+	}
+	return system.GitOK("check-ref-format", "--branch", name)
+}
+
+// RecreateBranch creates branch name at hash (existing branches are refused)
+func RecreateBranch(name, hash string) (Result, error) {
+	res := newResult("recreate-branch")
+
+	if name == "" || hash == "" {
+		return res, ErrInvalidArguments
+	}
+	if system.GitOK("show-ref", "--verify", "--quiet", "refs/heads/"+name) {
+		return res, fmt.Errorf("%w: branch %s already exists", ErrInvalidArguments, name)
+	}
+
+	if err := res.git("branch", name, hash); err != nil {
+		return res, err
+	}
+
+	res.addRef(name)
+	res.Summary = "Branch " + name + " recreated at " + shortHash(hash)
+	return res, nil
+}
+
+/*
+DroppedStashes finds stash commits no longer reachable (git fsck)
+Older entries of the stash list only live in the refs/stash reflog,
+so fsck --no-reflogs reports them too: they are skipped
+*/
+func DroppedStashes() ([]DroppedStash, error) {
+	out, err := system.GitRaw("fsck", "--unreachable", "--no-reflogs", "--no-progress")
+	if err != nil {
+		return nil, err
+	}
+
+	listed := map[string]bool{}
+	if list, err := system.GitRaw("stash", "list", "--format=%H"); err == nil {
+		for _, h := range splitLines(list) {
+			listed[h] = true
+		}
+	}
+
+	var hashes []string
+	for _, l := range strings.Split(out, "\n") {
+		f := strings.Fields(l)
+		if len(f) == 3 && f[0] == "unreachable" && f[1] == "commit" && !listed[f[2]] {
+			hashes = append(hashes, f[2])
+		}
+	}
+	if len(hashes) == 0 {
+		return nil, nil
+	}
+
+	args := append([]string{"log", "--no-walk", "--merges", "--format=%H%x1f%s%x1f%ct"}, hashes...)
+	out, err = system.GitRaw(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	var stashes []DroppedStash
+	for _, l := range strings.Split(out, "\n") {
+		f := strings.Split(l, fieldSep)
+		if len(f) != 3 || !isStashSubject(f[1]) {
+			continue
+		}
+		stashes = append(stashes, DroppedStash{Hash: f[0], Message: f[1], When: unixTime(f[2])})
+	}
+	return stashes, nil
+}
+
+// isStashSubject matches "WIP on main: …" and "On main: …"
+func isStashSubject(s string) bool {
+	return (strings.HasPrefix(s, "WIP on ") || strings.HasPrefix(s, "On ")) && strings.Contains(s, ": ")
+}
+
+// RecoverStash puts a dropped stash back on the stash list
+func RecoverStash(s DroppedStash) (Result, error) {
+	res := newResult("recover-stash")
+
+	if err := res.git("stash", "store", "-m", s.Message, s.Hash); err != nil {
+		return res, err
+	}
+
+	res.addRef("stash@{0}")
+	res.Summary = "Stash recovered as stash@{0}"
+	return res, nil
+}
+
+/* ============================================================
+   HELPERS
+   ============================================================ */
+
+func unixTime(s string) time.Time {
+	n, err := strconv.ParseInt(strings.TrimSpace(s), 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(n, 0)
+}
+
+func shortHash(h string) string {
+	if len(h) > 7 {
+		return h[:7]
+	}
+	return h
+}
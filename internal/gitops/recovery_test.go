@@ -0,0 +1,134 @@
+package gitops
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"git-genius/internal/system"
+)
+
+func TestReadReflog(t *testing.T) {
+	fake := useFake(t)
+	row := func(f ...string) string { return strings.Join(f, fieldSep) }
+	fake.On("reflog show --format=%gd%x1f%H%x1f%gs%x1f%ct -n 3 HEAD", system.Response{Stdout: strings.Join([]string{
+		row("HEAD@{0}", "aaa", "rebase (finish): returning to refs/heads/main", "1700000300"),
+		row("HEAD@{1}", "bbb", "checkout: moving from dev to main", "1700000200"),
+		row("HEAD@{2}", "ccc", "commit (initial)", "1700000100"),
+		"",
+	}, "\n")})
+
+	got, err := readReflog(3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []ReflogEntry{
+		{Selector: "HEAD@{0}", Hash: "aaa", Action: "rebase", Message: "finish: returning to refs/heads/main", When: time.Unix(1700000300, 0)},
+		{Selector: "HEAD@{1}", Hash: "bbb", Action: "checkout", Message: "moving from dev to main", When: time.Unix(1700000200, 0)},
+		{Selector: "HEAD@{2}", Hash: "ccc", Action: "other", Message: "commit (initial)", When: time.Unix(1700000100, 0)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readReflog()\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestSplitReflogSubject(t *testing.T) {
+	tests := []struct {
+		in, action, msg string
+	}{
+		{"commit: add parser", "commit", "add parser"},
+		{"commit (amend): add parser", "commit", "amend: add parser"},
+		{"reset: moving to HEAD~1", "reset", "moving to HEAD~1"},
+		{"no separator", "other", "no separator"},
+	}
+
+	for _, tt := range tests {
+		action, msg := splitReflogSubject(tt.in)
+		if action != tt.action || msg != tt.msg {
+			t.Errorf("splitReflogSubject(%q) = %q, %q; want %q, %q", tt.in, action, msg, tt.action, tt.msg)
+		}
+	}
+}
+
+func TestDroppedStashesSkipsStashList(t *testing.T) {
+	dir, git := tempRepo(t)
+	edit := func(text string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, "f.txt"), []byte(text), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	edit("base\n")
+	git("add", "f.txt")
+	git("commit", "-q", "-m", "base")
+
+	for _, msg := range []string{"first", "second", "third"} {
+		edit(msg + "\n")
+		git("stash", "push", "-q", "-m", msg)
+	}
+	dropped := strings.TrimSpace(git("rev-parse", "stash@{1}"))
+	git("stash", "drop", "-q", "stash@{1}")
+
+	got, err := DroppedStashes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Hash != dropped || !strings.HasSuffix(got[0].Message, ": second") {
+		t.Errorf("DroppedStashes() = %+v, want only the dropped %s", got, dropped)
+	}
+}
+
+func TestBackups(t *testing.T) {
+	_, git := tempRepo(t)
+	git("commit", "-q", "--allow-empty", "-m", "old commit", "--date", "2001-01-01T00:00:00")
+
+	start := time.Now().Add(-time.Second)
+	first, err := CreateBackup("restore-head")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := CreateBackup("restore-head")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first == second {
+		t.Fatalf("two backups in the same second share %s", first)
+	}
+
+	backups, err := Backups()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(backups) != 2 || backups[0].Ref != second || backups[1].Ref != first {
+		t.Fatalf("Backups() = %+v, want newest first", backups)
+	}
+	for _, b := range backups {
+		if b.When.Before(start) || b.Operation != "restore-head" {
+			t.Errorf("backup %s: when %v (commit is from 2001), operation %q", b.Ref, b.When, b.Operation)
+		}
+	}
+}
+
+func TestParseBackupName(t *testing.T) {
+	tests := []struct {
+		name string
+		when time.Time
+		op   string
+	}{
+		{"1700000000-undo-operation", time.Unix(1700000000, 0), "undo-operation"},
+		{"1700000000.000000042-restore-head", time.Unix(1700000000, 42), "restore-head"},
+		{"manual", time.Time{}, ""},
+	}
+
+	for _, tt := range tests {
+		when, op := parseBackupName(tt.name)
+		if !when.Equal(tt.when) || op != tt.op {
+			t.Errorf("parseBackupName(%q) = %v, %q; want %v, %q", tt.name, when, op, tt.when, tt.op)
+		}
+	}
+}
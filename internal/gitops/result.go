@@ -0,0 +1,55 @@
+package gitops
+
+import "git-genius/internal/system"
+
+/*
+Result describes what an operation did
+Summary  = one-line outcome for humans ("Changes pushed successfully")
+Refs     = branches / remote refs / stash refs touched
+Warnings = non-fatal problems the frontend should show
+Output   = captured git output
+Data     = operation specific payload (status files, stash entries…)
+
+gitops never prints: the menu / CLI layer renders results.
+*/
+type Result struct {
+	Operation string   `json:"operation"`
+	Summary   string   `json:"summary,omitempty"`
+	Refs      []string `json:"refs,omitempty"`
+	Warnings  []string `json:"warnings,omitempty"`
+	Output    string   `json:"output,omitempty"`
+	Data      any      `json:"data,omitempty"`
+}
+
+func newResult(op string) Result {
+	return Result{Operation: op}
+}
+
+func (r *Result) warn(msg string) {
+	r.Warnings = append(r.Warnings, msg)
+}
+
+func (r *Result) addRef(refs ...string) {
+	for _, ref := range refs {
+		if ref != "" && ref != "-" {
+			r.Refs = append(r.Refs, ref)
+		}
+	}
+}
+
+// git runs a command, appends its output and returns a typed error
+func (r *Result) git(args ...string) error {
+	return r.gitEnv(nil, args...)
+}
+
+// gitEnv is git with extra environment (GIT_EDITOR=true for --continue)
+func (r *Result) gitEnv(env []string, args ...string) error {
+	out, err := system.GitCombinedEnv(env, args...)
+	if out != "" {
+		if r.Output != "" {
+			r.Output += "\n"
+		}
+		r.Output += out
+	}
+	return gitErr(err, out, args...)
+}
@@ -0,0 +1,117 @@
+package gitops
+
+import (
+	"strings"
+
+	"git-genius/internal/config"
+	"git-genius/internal/secrets"
+	"git-genius/internal/system"
+)
+
+/* ============================================================
+   SECRET SCANNING (before commit / push)
+   ============================================================ */
+
+// ScanStaged scans what is about to be committed (index vs HEAD)
+func ScanStaged() ([]secrets.Finding, error) {
+	allow := secrets.LoadAllowlist(config.Load().GetWorkDir())
+
+	diff, err := system.GitRaw("diff", "--cached", "--no-color", "--no-ext-diff", "-U0")
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := system.GitRaw("diff", "--cached", "--name-only", "--diff-filter=ACMR")
+	if err != nil {
+		return nil, err
+	}
+
+	findings := secrets.ScanPaths(splitLines(names), allow)
+	return append(findings, secrets.ScanDiff(diff, allow)...), nil
+}
+
+// ScanOutgoing scans commits that no ref of remote contains yet
+func ScanOutgoing(remote string) ([]secrets.Finding, error) {
+	return scanOutgoingRevs(remote, []string{"HEAD"})
+}
+
+// scanOutgoingRevs scans the commits of revs that remote does not have
+func scanOutgoingRevs(remote string, revs []string) ([]secrets.Finding, error) {
+	allow := secrets.LoadAllowlist(config.Load().GetWorkDir())
+
+	log, err := system.GitRaw(outgoingArgs(remote, revs, "log", "-p", "--no-color", "--no-ext-diff", "-U0", "--format=commit %H")...)
+	if err != nil {
+		return nil, err
+	}
+
+	// .env files added in outgoing commits
+	var findings []secrets.Finding
+	names, err := system.GitRaw(outgoingArgs(remote, revs, "log", "--name-only", "--diff-filter=ACR", "--format=commit %H")...)
+	if err == nil {
+		commit := ""
+		for _, l := range splitLines(names) {
+			if strings.HasPrefix(l, "commit ") {
+				commit = strings.TrimPrefix(l, "commit ")
+				continue
+			}
+			for _, f := range secrets.ScanPaths([]string{l}, allow) {
+				f.Commit = commit
+				findings = append(findings, f)
+			}
+		}
+	}
+
+	return append(findings, secrets.ScanDiff(log, allow)...), nil
+}
+
+// hasOutgoingCommits reports local commits missing from remote
+func hasOutgoingCommits(remote string) bool {
+	out, err := system.GitRaw(outgoingArgs(remote, []string{"HEAD"}, "rev-list", "--count")...)
+	return err == nil && strings.TrimSpace(out) != "0"
+}
+
+// outgoingArgs limits a log / rev-list to revs minus what remote has
+func outgoingArgs(remote string, revs []string, args ...string) []string {
+	args = append(args, revs...)
+	return append(args, "--not", "--remotes="+remote)
+}
+
+/*
+checkOutgoing runs the push gates on the commits of revs remote does not
+have yet: secret scan, then files above config.LargeFileMB
+Used where commits are pushed without going through Push
+*/
+func (r *Result) checkOutgoing(remote string, revs ...string) error {
+	findings, err := scanOutgoingRevs(remote, revs)
+	if err != nil {
+		return err
+	}
+	if len(findings) > 0 {
+		return r.secretsFound(findings)
+	}
+
+	files, err := outgoingLargeFiles(remote, revs, int64(config.Load().LargeFileMB)<<20)
+	if err != nil {
+		return err
+	}
+	if len(files) > 0 {
+		return r.largeFilesFound(files)
+	}
+	return nil
+}
+
+// secretsFound stores findings on the result and returns ErrSecretsDetected
+func (r *Result) secretsFound(findings []secrets.Finding) error {
+	r.Data = findings
+	return ErrSecretsDetected
+}
+
+func splitLines(s string) []string {
+	var lines []string
+	for _, l := range strings.Split(s, "\n") {
+		if l = strings.TrimSpace(l); l != "" {
+			lines = append(lines, l)
+		}
+	}
+	return lines
+}
@@ -3,66 +3,72 @@ package gitops
 import (
 	"git-genius/internal/config"
 	"git-genius/internal/system"
-	"git-genius/internal/ui"
 )
 
 /*
 SmartPull performs:
 1. Detect dirty working tree
-2. Auto-stash changes (optional)
+2. Auto-stash changes (only when autoStash is true)
 3. Pull latest changes
 4. Restore stash (if created)
 
 This prevents pull failures due to local changes.
+Dirty tree + autoStash=false → ErrDirtyWorkTree (frontend asks the user)
 */
-func SmartPull() {
-	if !system.EnsureGitRepo() {
-		return
+func SmartPull(autoStash bool) (Result, error) {
+	return journaled("smart-pull", func() (Result, error) { return smartPull(autoStash) })
+}
+
+func smartPull(autoStash bool) (Result, error) {
+	res := newResult("smart-pull")
+
+	if !system.IsGitRepo() {
+		return res, ErrNotRepo
+	}
+
+	if err := checkNoPending(); err != nil {
+		return res, err
 	}
 
 	cfg := config.Load()
 	stashed := false
 
+	res.addRef(cfg.Branch, cfg.Remote+"/"+cfg.Branch)
+
 	// Step 1: Detect uncommitted changes
 	if isWorkingTreeDirty() {
-		ui.Warn("Uncommitted changes detected")
-
-		if !ui.Confirm("Auto-stash changes and continue pull?") {
-			ui.Warn("Smart pull cancelled")
-			return
+		if !autoStash {
+			return res, ErrDirtyWorkTree
 		}
 
-		if err := system.RunGit("stash", "push", "-m", "git-genius-auto-stash"); err != nil {
-			ui.Error("Failed to auto-stash changes")
-			return
+		if err := res.git("stash", "push", "-m", "git-genius-auto-stash"); err != nil {
+			return res, err
 		}
 
 		stashed = true
-		ui.Success("Changes stashed temporarily")
+		res.addRef("stash@{0}")
+		res.warn("Uncommitted changes were stashed temporarily")
 	}
 
 	// Step 2: Pull latest changes
-	ui.Info("Pulling latest changes...")
-	if err := system.RunGit("pull", cfg.Remote, cfg.Branch); err != nil {
-		ui.Error("Pull failed")
-
+	if err := res.git("pull", cfg.Remote, cfg.Branch); err != nil {
 		// Try restoring stash if pull failed
 		if stashed {
-			_ = system.RunGit("stash", "pop")
+			if res.git("stash", "pop") != nil {
+				res.warn("Auto-stash kept as stash@{0}")
+			}
 		}
-		return
+		return res, err
 	}
 
 	// Step 3: Restore stash if created
 	if stashed {
-		ui.Info("Restoring stashed changes...")
-		if err := system.RunGit("stash", "pop"); err != nil {
-			ui.Warn("Auto-stash could not be applied cleanly")
-			ui.Info("Resolve conflicts manually if needed")
-			return
+		if err := res.git("stash", "pop"); err != nil {
+			res.warn("Auto-stash could not be applied cleanly")
+			return res, err
 		}
-		ui.Success("Stashed changes restored")
 	}
 
-	ui.Success("Smart pull completed successfully")
+	res.Summary = "Smart pull completed successfully"
+	return res, nil
 }
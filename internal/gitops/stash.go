@@ -1,58 +1,366 @@
 package gitops
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"git-genius/internal/system"
-	"git-genius/internal/ui"
 )
 
+// StashEntry is one entry of the stash list
+type StashEntry struct {
+	Ref     string    `json:"ref"` // stash@{n} when listed
+	Hash    string    `json:"hash"`
+	Message string    `json:"message"`
+	Branch  string    `json:"branch"` // branch the changes were stashed on
+	WIP     bool      `json:"wip"`    // git's default "WIP on …" message
+	When    time.Time `json:"when"`
+}
+
+// StashOptions selects what StashSave puts away
+type StashOptions struct {
+	// Message of the stash; empty = git default ("WIP on <branch>")
+	Message string
+	// Untracked stashes new files too (git stash -u)
+	Untracked bool
+	// Ignored stashes untracked AND ignored files (git stash -a)
+	Ignored bool
+	// KeepIndex leaves staged changes in the work tree (they are stashed as well)
+	KeepIndex bool
+	// Paths limits the stash to these files; nil = everything
+	// Untracked files in Paths are included without setting Untracked
+	Paths []string
+}
+
 /*
-StashSave saves current working tree changes
+StashSave saves working tree changes according to opts
 */
-func StashSave() {
-	if !system.EnsureGitRepo() {
-		return
+func StashSave(opts StashOptions) (Result, error) {
+	return journaled("stash-save", func() (Result, error) { return stashSave(opts) })
+}
+
+func stashSave(opts StashOptions) (Result, error) {
+	res := newResult("stash-save")
+
+	if !system.IsGitRepo() {
+		return res, ErrNotRepo
+	}
+	if opts.Paths != nil && len(opts.Paths) == 0 {
+		return res, ErrNothingToCommit
+	}
+
+	// a path git does not track yet needs -u, otherwise the pathspec fails
+	if !opts.Untracked && !opts.Ignored && len(opts.Paths) > 0 {
+		out, _ := system.GitRaw(append([]string{"ls-files", "--others", "--exclude-standard", "--"}, opts.Paths...)...)
+		opts.Untracked = len(splitLines(out)) > 0
+	}
+
+	if !hasStashableChanges(opts) {
+		return res, ErrNothingToCommit
 	}
 
-	msg := ui.Input("Stash message (optional)")
 	args := []string{"stash", "push"}
 
-	if msg != "" {
-		args = append(args, "-m", msg)
+	if opts.Message != "" {
+		args = append(args, "-m", opts.Message)
+	}
+	switch {
+	case opts.Ignored:
+		args = append(args, "--all")
+	case opts.Untracked:
+		args = append(args, "--include-untracked")
+	}
+	if opts.KeepIndex {
+		args = append(args, "--keep-index")
+	}
+	if len(opts.Paths) > 0 {
+		args = append(append(args, "--"), opts.Paths...)
 	}
 
-	if err := system.RunGit(args...); err != nil {
-		ui.Error("Failed to stash changes")
-		return
+	if err := res.git(args...); err != nil {
+		return res, err
 	}
 
-	ui.Success("Changes stashed successfully")
+	res.addRef("stash@{0}")
+	res.Summary = "Changes stashed successfully"
+	if len(opts.Paths) > 0 {
+		res.Summary = fmt.Sprintf("Stashed changes in %d path(s)", len(opts.Paths))
+	}
+	switch {
+	case opts.Ignored:
+		res.Summary += " (untracked and ignored files included)"
+	case opts.Untracked:
+		res.Summary += " (untracked files included)"
+	}
+	if opts.KeepIndex {
+		res.warn("Staged changes were kept in your work tree (and are in the stash too)")
+	}
+	return res, nil
 }
 
 /*
-StashList shows all stashes
+hasStashableChanges mirrors what git stash push would save:
+without -u / -a a work tree with only new files has nothing to stash
+(git would exit successfully without creating a stash)
 */
-func StashList() {
-	if !system.EnsureGitRepo() {
-		return
+func hasStashableChanges(opts StashOptions) bool {
+	args := []string{"status", "--porcelain", "--untracked-files=no"}
+	switch {
+	case opts.Ignored:
+		args = []string{"status", "--porcelain", "--untracked-files=all", "--ignored"}
+	case opts.Untracked:
+		args = []string{"status", "--porcelain", "--untracked-files=all"}
+	}
+	if len(opts.Paths) > 0 {
+		args = append(append(args, "--"), opts.Paths...)
 	}
 
-	if err := system.RunGit("stash", "list"); err != nil {
-		ui.Error("Failed to list stashes")
+	out, _ := system.GitRaw(args...)
+	return strings.TrimSpace(out) != ""
+}
+
+/*
+ReadStashes parses the stash list (newest first)
+
+	stash@{0} <hash> <time> WIP on main: 1a2b3c4 last commit subject
+	stash@{1} <hash> <time> On feature: my message
+*/
+func ReadStashes() ([]StashEntry, error) {
+	out, err := system.GitRaw("stash", "list", "--format=%gd%x1f%H%x1f%ct%x1f%gs")
+	if err != nil {
+		return nil, gitErr(err, out, "stash", "list")
+	}
+
+	entries := []StashEntry{}
+	for _, l := range splitLines(out) {
+		f := strings.SplitN(l, fieldSep, 4)
+		if len(f) != 4 {
+			continue
+		}
+
+		e := StashEntry{Ref: f[0], Hash: f[1], When: unixTime(f[2]), Message: f[3]}
+		subject := f[3]
+		if rest, ok := strings.CutPrefix(subject, "WIP on "); ok {
+			e.WIP, subject = true, rest
+		} else {
+			subject = strings.TrimPrefix(subject, "On ")
+		}
+		if branch, msg, ok := strings.Cut(subject, ": "); ok {
+			e.Branch, e.Message = branch, msg
+		}
+		entries = append(entries, e)
 	}
+	return entries, nil
+}
+
+/*
+StashList returns all stashes (newest first)
+*/
+func StashList() (Result, error) {
+	res := newResult("stash-list")
+
+	if !system.IsGitRepo() {
+		return res, ErrNotRepo
+	}
+
+	entries, err := ReadStashes()
+	if err != nil {
+		return res, err
+	}
+
+	for _, e := range entries {
+		res.addRef(e.Ref)
+	}
+
+	if len(entries) == 0 {
+		res.Summary = "No stashes found"
+	}
+
+	res.Data = entries
+	return res, nil
 }
 
 /*
 StashPop applies and removes latest stash
 */
-func StashPop() {
-	if !system.EnsureGitRepo() {
-		return
+func StashPop() (Result, error) {
+	return journaled("stash-pop", stashPop)
+}
+
+func stashPop() (Result, error) {
+	res := newResult("stash-pop")
+
+	if !system.IsGitRepo() {
+		return res, ErrNotRepo
 	}
 
-	if err := system.RunGit("stash", "pop"); err != nil {
-		ui.Error("Failed to apply stash")
-		return
+	res.addRef("stash@{0}")
+
+	if err := res.git("stash", "pop"); err != nil {
+		return res, err
 	}
 
-	ui.Success("Stash applied successfully")
+	res.Summary = "Stash applied successfully"
+	return res, nil
+}
+
+/* ============================================================
+   PER-ENTRY ACTIONS
+   ============================================================
+
+   Entries are addressed by hash: stash@{n} shifts whenever a
+   stash is added or removed, so the ref is looked up right
+   before git runs (ErrStateChanged when the entry is gone).
+*/
+
+// stashRef finds the current stash@{n} of e
+func stashRef(e StashEntry) (string, error) {
+	if !system.IsGitRepo() {
+		return "", ErrNotRepo
+	}
+	ref, ok := stashRefFor(e.Hash)
+	if !ok {
+		return "", fmt.Errorf("%w: %s is no longer on the stash list", ErrStateChanged, shortHash(e.Hash))
+	}
+	return ref, nil
+}
+
+// StashChanges is the diff request for everything e saved
+func StashChanges(e StashEntry) DiffRequest {
+	return DiffRequest{Source: DiffStash, From: e.Hash}
+}
+
+// StashApply applies e and keeps it on the stash list
+func StashApply(e StashEntry) (Result, error) {
+	return journaled("stash-apply", func() (Result, error) {
+		res := newResult("stash-apply")
+
+		ref, err := stashRef(e)
+		if err != nil {
+			return res, err
+		}
+		res.addRef(ref)
+
+		if err := res.git("stash", "apply", ref); err != nil {
+			return res, err
+		}
+
+		res.Summary = "Applied " + ref + " (kept on the stash list)"
+		return res, nil
+	})
+}
+
+// StashPopEntry applies e and removes it (kept when the apply conflicts)
+func StashPopEntry(e StashEntry) (Result, error) {
+	return journaled("stash-pop", func() (Result, error) {
+		res := newResult("stash-pop")
+
+		ref, err := stashRef(e)
+		if err != nil {
+			return res, err
+		}
+		res.addRef(ref)
+
+		if err := res.git("stash", "pop", ref); err != nil {
+			return res, err
+		}
+
+		res.Summary = "Applied and removed " + ref
+		return res, nil
+	})
+}
+
+// StashDrop deletes e (Undo last git-genius operation brings it back)
+func StashDrop(e StashEntry) (Result, error) {
+	return journaled("stash-drop", func() (Result, error) {
+		res := newResult("stash-drop")
+
+		ref, err := stashRef(e)
+		if err != nil {
+			return res, err
+		}
+		res.addRef(ref)
+
+		if err := res.git("stash", "drop", ref); err != nil {
+			return res, err
+		}
+
+		res.Summary = fmt.Sprintf("Dropped %s (%s)", ref, shortHash(e.Hash))
+		return res, nil
+	})
+}
+
+/*
+StashRename changes the message of e
+git has no rename: the commit is stored again with the new message and the
+old entry dropped, so the stash moves to stash@{0}
+*/
+func StashRename(e StashEntry, msg string) (Result, error) {
+	res := newResult("stash-rename")
+
+	msg = strings.TrimSpace(msg)
+	if msg == "" {
+		return res, ErrEmptyMessage
+	}
+
+	ref, err := stashRef(e)
+	if err != nil {
+		return res, err
+	}
+
+	branch := e.Branch
+	if branch == "" {
+		branch = "(no branch)"
+	}
+
+	var n int
+	if _, err := fmt.Sscanf(ref, "stash@{%d}", &n); err != nil {
+		return res, fmt.Errorf("%w: %s", ErrInvalidArguments, ref)
+	}
+
+	// store first so the commit stays referenced; the old entry moves down one
+	if err := res.git("stash", "store", "-m", "On "+branch+": "+msg, e.Hash); err != nil {
+		return res, err
+	}
+	if err := res.git("stash", "drop", "--quiet", fmt.Sprintf("stash@{%d}", n+1)); err != nil {
+		return res, err
+	}
+
+	res.addRef("stash@{0}")
+	res.Summary = "Renamed stash (now stash@{0}): " + msg
+	return res, nil
+}
+
+/*
+StashBranch creates branch name at the commit e was made on, switches to it
+and applies e there (git stash branch); the stash is dropped when it applies cleanly
+*/
+func StashBranch(e StashEntry, name string) (Result, error) {
+	return journaled("stash-branch", func() (Result, error) {
+		res := newResult("stash-branch")
+
+		if err := checkNoPending(); err != nil {
+			return res, err
+		}
+		if name == "" || !system.GitOK("check-ref-format", "--branch", name) {
+			return res, fmt.Errorf("%w: %q", ErrInvalidBranchName, name)
+		}
+		if refExists("refs/heads/" + name) {
+			return res, fmt.Errorf("%w: %s already exists", ErrInvalidBranchName, name)
+		}
+
+		ref, err := stashRef(e)
+		if err != nil {
+			return res, err
+		}
+
+		if err := res.git("stash", "branch", name, ref); err != nil {
+			return res, err
+		}
+
+		res.addRef(name, ref)
+		res.Summary = "Created branch " + name + " from " + ref
+		return res, nil
+	})
 }
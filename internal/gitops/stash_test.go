@@ -0,0 +1,44 @@
+package gitops
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"git-genius/internal/system"
+)
+
+func TestReadStashes(t *testing.T) {
+	fake := useFake(t)
+	row := func(f ...string) string { return strings.Join(f, fieldSep) }
+	fake.On("stash list --format=%gd%x1f%H%x1f%ct%x1f%gs", system.Response{Stdout: strings.Join([]string{
+		row("stash@{0}", "aaa", "1700000200", "On feature/login: half-done form"),
+		row("stash@{1}", "bbb", "1700000100", "WIP on main: 1a2b3c4 fix: typo"),
+		row("stash@{2}", "ccc", "1700000000", "autostash"),
+		"",
+	}, "\n")})
+
+	got, err := ReadStashes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []StashEntry{
+		{Ref: "stash@{0}", Hash: "aaa", Branch: "feature/login", Message: "half-done form", When: time.Unix(1700000200, 0)},
+		{Ref: "stash@{1}", Hash: "bbb", Branch: "main", Message: "1a2b3c4 fix: typo", WIP: true, When: time.Unix(1700000100, 0)},
+		{Ref: "stash@{2}", Hash: "ccc", Message: "autostash", When: time.Unix(1700000000, 0)},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadStashes()\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestReadStashesEmpty(t *testing.T) {
+	useFake(t)
+
+	got, err := ReadStashes()
+	if err != nil || len(got) != 0 {
+		t.Errorf("ReadStashes() = %v, %v; want no entries", got, err)
+	}
+}
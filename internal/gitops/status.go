@@ -0,0 +1,202 @@
+package gitops
+
+import (
+	"strconv"
+	"strings"
+
+	"git-genius/internal/system"
+)
+
+/* ============================================================
+   STATUS MODEL (git status --porcelain=v2 --branch -z)
+   ============================================================ */
+
+// StatusEntry is one changed path
+// Index / Worktree hold git's X / Y codes ("M", "A", "D", "R", "." …)
+type StatusEntry struct {
+	Path     string `json:"path"`
+	OrigPath string `json:"orig_path,omitempty"` // renames / copies
+	Index    string `json:"index"`
+	Worktree string `json:"worktree"`
+}
+
+// RepoStatus is the parsed repository state
+type RepoStatus struct {
+	Branch     string        `json:"branch"` // empty when detached
+	Head       string        `json:"head"`   // commit id, empty before first commit
+	Detached   bool          `json:"detached"`
+	Upstream   string        `json:"upstream,omitempty"`
+	Ahead      int           `json:"ahead"`
+	Behind     int           `json:"behind"`
+	Staged     []StatusEntry `json:"staged"`
+	Unstaged   []StatusEntry `json:"unstaged"`
+	Untracked  []StatusEntry `json:"untracked"`
+	Conflicted []StatusEntry `json:"conflicted"`
+	Renamed    []StatusEntry `json:"renamed"`
+}
+
+// Clean reports a working tree without any change
+func (s RepoStatus) Clean() bool {
+	return len(s.Staged) == 0 && len(s.Unstaged) == 0 &&
+		len(s.Untracked) == 0 && len(s.Conflicted) == 0
+}
+
+// Paths returns every changed path once (staged, unstaged, untracked, conflicted)
+func (s RepoStatus) Paths() []string {
+	seen := map[string]bool{}
+	var paths []string
+
+	for _, group := range [][]StatusEntry{s.Conflicted, s.Staged, s.Unstaged, s.Untracked} {
+		for _, e := range group {
+			if !seen[e.Path] {
+				seen[e.Path] = true
+				paths = append(paths, e.Path)
+			}
+		}
+	}
+	return paths
+}
+
+// ReadStatus runs git status and parses it
+func ReadStatus() (RepoStatus, error) {
+	if !system.IsGitRepo() {
+		return RepoStatus{}, ErrNotRepo
+	}
+
+	args := []string{"status", "--porcelain=v2", "--branch", "-z", "--untracked-files=all"}
+	out, err := system.GitRaw(args...)
+	if err != nil {
+		return RepoStatus{}, gitErr(err, out, args...)
+	}
+	return ParseStatus(out), nil
+}
+
+/*
+ParseStatus parses `git status --porcelain=v2 --branch -z` output
+
+Records:
+
+	# branch.oid / branch.head / branch.upstream / branch.ab
+	1 <XY> …8 fields… <path>                    ordinary change
+	2 <XY> …9 fields… <path> NUL <origPath>     rename / copy
+	u <XY> …10 fields… <path>                   unmerged
+	? <path>                                    untracked
+*/
+func ParseStatus(out string) RepoStatus {
+	st := RepoStatus{
+		Staged:     []StatusEntry{},
+		Unstaged:   []StatusEntry{},
+		Untracked:  []StatusEntry{},
+		Conflicted: []StatusEntry{},
+		Renamed:    []StatusEntry{},
+	}
+
+	records := strings.Split(out, "\x00")
+	for i := 0; i < len(records); i++ {
+		rec := records[i]
+		if rec == "" {
+			continue
+		}
+
+		switch rec[0] {
+		case '#':
+			parseBranchHeader(&st, rec)
+
+		case '1':
+			if f := strings.SplitN(rec, " ", 9); len(f) == 9 {
+				addEntry(&st, f[1], StatusEntry{Path: f[8]})
+			}
+
+		case '2':
+			if f := strings.SplitN(rec, " ", 10); len(f) == 10 {
+				e := StatusEntry{Path: f[9]}
+				if i+1 < len(records) {
+					i++
+					e.OrigPath = records[i]
+				}
+				addEntry(&st, f[1], e)
+				st.Renamed = append(st.Renamed, withCodes(e, f[1]))
+			}
+
+		case 'u':
+			if f := strings.SplitN(rec, " ", 11); len(f) == 11 {
+				st.Conflicted = append(st.Conflicted, withCodes(StatusEntry{Path: f[10]}, f[1]))
+			}
+
+		case '?':
+			st.Untracked = append(st.Untracked, StatusEntry{
+				Path: strings.TrimPrefix(rec, "? "), Index: "?", Worktree: "?",
+			})
+		}
+	}
+
+	return st
+}
+
+func parseBranchHeader(st *RepoStatus, rec string) {
+	fields := strings.Fields(rec)
+	if len(fields) < 3 {
+		return
+	}
+
+	switch fields[1] {
+	case "branch.oid":
+		if fields[2] != "(initial)" {
+			st.Head = fields[2]
+		}
+	case "branch.head":
+		if fields[2] == "(detached)" {
+			st.Detached = true
+		} else {
+			st.Branch = fields[2]
+		}
+	case "branch.upstream":
+		st.Upstream = fields[2]
+	case "branch.ab":
+		if len(fields) == 4 {
+			st.Ahead, _ = strconv.Atoi(strings.TrimPrefix(fields[2], "+"))
+			st.Behind, _ = strconv.Atoi(strings.TrimPrefix(fields[3], "-"))
+		}
+	}
+}
+
+func withCodes(e StatusEntry, xy string) StatusEntry {
+	if len(xy) == 2 {
+		e.Index = xy[0:1]
+		e.Worktree = xy[1:2]
+	}
+	return e
+}
+
+func addEntry(st *RepoStatus, xy string, e StatusEntry) {
+	e = withCodes(e, xy)
+	if e.Index != "" && e.Index != "." {
+		st.Staged = append(st.Staged, e)
+	}
+	if e.Worktree != "" && e.Worktree != "." {
+		st.Unstaged = append(st.Unstaged, e)
+	}
+}
+
+// ChangeLabel turns a status code into a readable word
+func ChangeLabel(code string) string {
+	switch code {
+	case "M":
+		return "modified"
+	case "A":
+		return "added"
+	case "D":
+		return "deleted"
+	case "R":
+		return "renamed"
+	case "C":
+		return "copied"
+	case "T":
+		return "type changed"
+	case "U":
+		return "conflict"
+	case "?":
+		return "untracked"
+	}
+	return "changed"
+}
@@ -0,0 +1,116 @@
+package gitops
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"git-genius/internal/system"
+)
+
+func TestParseStatus(t *testing.T) {
+	rec := func(records ...string) string { return strings.Join(records, "\x00") + "\x00" }
+
+	tests := []struct {
+		name string
+		out  string
+		want RepoStatus
+	}{
+		{
+			name: "branch with upstream",
+			out: rec(
+				"# branch.oid 1a2b3c4d",
+				"# branch.head main",
+				"# branch.upstream origin/main",
+				"# branch.ab +2 -1",
+			),
+			want: RepoStatus{Branch: "main", Head: "1a2b3c4d", Upstream: "origin/main", Ahead: 2, Behind: 1},
+		},
+		{
+			name: "detached before first commit",
+			out:  rec("# branch.oid (initial)", "# branch.head (detached)"),
+			want: RepoStatus{Detached: true},
+		},
+		{
+			name: "staged and unstaged",
+			out: rec(
+				"# branch.head main",
+				"1 M. N... 100644 100644 100644 aaa bbb staged.go",
+				"1 .M N... 100644 100644 100644 aaa aaa work tree.go",
+				"1 MM N... 100644 100644 100644 aaa bbb both.go",
+			),
+			want: RepoStatus{
+				Branch: "main",
+				Staged: []StatusEntry{
+					{Path: "staged.go", Index: "M", Worktree: "."},
+					{Path: "both.go", Index: "M", Worktree: "M"},
+				},
+				Unstaged: []StatusEntry{
+					{Path: "work tree.go", Index: ".", Worktree: "M"},
+					{Path: "both.go", Index: "M", Worktree: "M"},
+				},
+			},
+		},
+		{
+			name: "rename keeps the original path",
+			out: rec(
+				"2 R. N... 100644 100644 100644 aaa aaa R100 new name.go",
+				"old name.go",
+				"? notes.txt",
+			),
+			want: RepoStatus{
+				Staged:    []StatusEntry{{Path: "new name.go", OrigPath: "old name.go", Index: "R", Worktree: "."}},
+				Renamed:   []StatusEntry{{Path: "new name.go", OrigPath: "old name.go", Index: "R", Worktree: "."}},
+				Untracked: []StatusEntry{{Path: "notes.txt", Index: "?", Worktree: "?"}},
+			},
+		},
+		{
+			name: "unmerged",
+			out: rec(
+				"u UU N... 100644 100644 100644 100644 aaa bbb ccc conflict.go",
+				"u AA N... 000000 100644 100644 100644 000 bbb ccc both added.go",
+			),
+			want: RepoStatus{
+				Conflicted: []StatusEntry{
+					{Path: "conflict.go", Index: "U", Worktree: "U"},
+					{Path: "both added.go", Index: "A", Worktree: "A"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ParseStatus(tt.out)
+			want := withEmptyGroups(tt.want)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ParseStatus()\n got %+v\nwant %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestReadStatusUsesPorcelainV2(t *testing.T) {
+	fake := useFake(t)
+	fake.On("status --porcelain=v2 --branch -z --untracked-files=all", system.Response{
+		Stdout: "# branch.head dev\x00? new.txt\x00",
+	})
+
+	st, err := ReadStatus()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.Branch != "dev" || len(st.Untracked) != 1 || st.Untracked[0].Path != "new.txt" {
+		t.Errorf("ReadStatus() = %+v", st)
+	}
+}
+
+// withEmptyGroups matches ParseStatus, which never returns nil groups
+func withEmptyGroups(st RepoStatus) RepoStatus {
+	for _, g := range []*[]StatusEntry{&st.Staged, &st.Unstaged, &st.Untracked, &st.Conflicted, &st.Renamed} {
+		if *g == nil {
+			*g = []StatusEntry{}
+		}
+	}
+	return st
+}
@@ -0,0 +1,356 @@
+package gitops
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"git-genius/internal/commitmsg"
+	"git-genius/internal/config"
+	"git-genius/internal/semver"
+	"git-genius/internal/system"
+)
+
+/* ============================================================
+   TAGS + RELEASES (semantic versions)
+   ============================================================ */
+
+// TagInfo is one tag
+type TagInfo struct {
+	Name          string    `json:"name"`
+	Commit        string    `json:"commit"`
+	Annotated     bool      `json:"annotated"`
+	Subject       string    `json:"subject,omitempty"` // tag message (annotated) or commit subject
+	CommitSubject string    `json:"commit_subject"`
+	Tagger        string    `json:"tagger,omitempty"`
+	When          time.Time `json:"when"`
+	Version       string    `json:"version,omitempty"` // normalised semver, empty when not a version
+}
+
+// ReleaseCommit is a commit with its Conventional Commit parts
+type ReleaseCommit struct {
+	Hash         string            `json:"hash"`
+	Author       string            `json:"author"`
+	When         time.Time         `json:"when"`
+	Subject      string            `json:"subject"`
+	Message      commitmsg.Message `json:"message"`
+	Conventional bool              `json:"conventional"`
+}
+
+// ReleasePlan is what the next release would contain
+type ReleasePlan struct {
+	CurrentTag string          `json:"current_tag,omitempty"` // latest version tag reachable from HEAD
+	Current    semver.Version  `json:"current"`
+	Commits    []ReleaseCommit `json:"commits"` // since CurrentTag, newest first
+	Breaking   int             `json:"breaking"`
+	Features   int             `json:"features"`
+	Fixes      int             `json:"fixes"`
+	Suggested  string          `json:"suggested"` // major, minor, patch
+	Next       semver.Version  `json:"next"`
+	Unreleased bool            `json:"unreleased"` // there are commits after the tag
+}
+
+// ListTags returns every tag, newest first
+func ListTags() ([]TagInfo, error) {
+	out, err := system.GitRaw("for-each-ref", "--sort=-creatordate",
+		"--format=%(refname:short)%1f%(objecttype)%1f%(objectname)%1f%(*objectname)%1f%(creatordate:unix)%1f%(contents:subject)%1f%(taggername)%1f%(*contents:subject)",
+		"refs/tags")
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []TagInfo
+	for _, l := range splitLines(out) {
+		f := strings.Split(l, fieldSep)
+		if len(f) != 8 {
+			continue
+		}
+		t := TagInfo{
+			Name:      f[0],
+			Commit:    f[2],
+			Annotated: f[1] == "tag",
+			When:      unixTime(f[4]),
+			Subject:   f[5],
+			Tagger:    f[6],
+		}
+		t.CommitSubject = t.Subject
+		if t.Annotated {
+			t.Commit, t.CommitSubject = f[3], f[7]
+		}
+		if v, ok := semver.Parse(t.Name); ok {
+			t.Version = v.String()
+		}
+		tags = append(tags, t)
+	}
+	return tags, nil
+}
+
+// Tags lists tags (Result.Data = []TagInfo)
+func Tags() (Result, error) {
+	res := newResult("tags")
+
+	if !system.IsGitRepo() {
+		return res, ErrNotRepo
+	}
+
+	tags, err := ListTags()
+	if err != nil {
+		return res, err
+	}
+
+	res.Data = tags
+	res.Summary = fmt.Sprintf("%d tag(s)", len(tags))
+	return res, nil
+}
+
+/*
+LatestVersionTag is the highest semver tag reachable from rev
+ok = false when no version tag exists yet
+*/
+func LatestVersionTag(rev string) (string, semver.Version, bool) {
+	out, err := system.GitRaw("tag", "--merged", rev)
+	if err != nil {
+		return "", semver.Version{}, false
+	}
+
+	var best string
+	var bestV semver.Version
+	for _, name := range splitLines(out) {
+		v, ok := semver.Parse(name)
+		if !ok {
+			continue
+		}
+		if best == "" || semver.Compare(v, bestV) > 0 {
+			best, bestV = name, v
+		}
+	}
+	return best, bestV, best != ""
+}
+
+/*
+CommitsBetween lists commits in from..to, newest first
+from "" = everything reachable from to; merge commits are skipped
+*/
+func CommitsBetween(from, to string) ([]ReleaseCommit, error) {
+	rng := to
+	if from != "" {
+		rng = from + ".." + to
+	}
+
+	args := []string{"log", "--no-merges", "--format=%H%x1f%an%x1f%ct%x1f%B" + logMarker, rng, "--"}
+	out, err := system.GitRaw(args...)
+	if err != nil {
+		return nil, gitErr(err, out, args...)
+	}
+
+	var commits []ReleaseCommit
+	for _, rec := range strings.Split(out, logMarker) {
+		f := strings.SplitN(strings.TrimLeft(rec, "\n"), fieldSep, 4)
+		if len(f) != 4 {
+			continue
+		}
+		msg, ok := commitmsg.Parse(f[3])
+		header, _, _ := strings.Cut(strings.TrimSpace(f[3]), "\n")
+		commits = append(commits, ReleaseCommit{
+			Hash:         f[0],
+			Author:       f[1],
+			When:         unixTime(f[2]),
+			Subject:      header,
+			Message:      msg,
+			Conventional: ok,
+		})
+	}
+	return commits, nil
+}
+
+// PlanRelease reads the latest version tag and suggests the next version
+func PlanRelease() (ReleasePlan, error) {
+	var plan ReleasePlan
+
+	if !system.IsGitRepo() {
+		return plan, ErrNotRepo
+	}
+	if !hasAnyCommit() {
+		return plan, ErrNoCommits
+	}
+
+	tag, current, ok := LatestVersionTag("HEAD")
+	if ok {
+		plan.CurrentTag, plan.Current = tag, current
+	} else {
+		plan.Current = semver.Version{Prefix: "v"}
+	}
+
+	commits, err := CommitsBetween(plan.CurrentTag, "HEAD")
+	if err != nil {
+		return plan, err
+	}
+	plan.Commits = commits
+	plan.Unreleased = len(commits) > 0
+
+	for _, c := range commits {
+		switch {
+		case c.Message.Breaking:
+			plan.Breaking++
+		case c.Message.Type == "feat":
+			plan.Features++
+		case c.Message.Type == "fix":
+			plan.Fixes++
+		}
+	}
+
+	plan.Suggested = semver.Suggest(plan.Current, plan.Breaking > 0, plan.Features > 0)
+	plan.Next = plan.Current.Bump(plan.Suggested)
+	if !ok {
+		// first release starts at 0.1.0
+		plan.Next = semver.Version{Prefix: "v", Minor: 1}
+		plan.Suggested = semver.Minor
+	}
+	return plan, nil
+}
+
+/*
+CreateTag tags target (empty = HEAD) with an annotated or signed tag
+Signing uses the user's gpg / ssh signing setup (git tag -s)
+*/
+func CreateTag(name, message, target string, sign bool) (Result, error) {
+	return journaled("create-tag", func() (Result, error) { return createTag(name, message, target, sign) })
+}
+
+func createTag(name, message, target string, sign bool) (Result, error) {
+	res := newResult("create-tag")
+
+	if !system.IsGitRepo() {
+		return res, ErrNotRepo
+	}
+	if !hasAnyCommit() {
+		return res, ErrNoCommits
+	}
+	if name == "" || !system.GitOK("check-ref-format", "refs/tags/"+name) {
+		return res, fmt.Errorf("%w: %q", ErrInvalidTagName, name)
+	}
+	if refExists("refs/tags/" + name) {
+		return res, fmt.Errorf("%w: %s", ErrTagExists, name)
+	}
+	if strings.TrimSpace(message) == "" {
+		message = "Release " + name
+	}
+	if target == "" {
+		target = "HEAD"
+	}
+
+	args := []string{"tag", "-a", "-m", message, name, target}
+	kind := "annotated"
+	if sign {
+		args[1], kind = "-s", "signed"
+	}
+
+	if err := res.git(args...); err != nil {
+		return res, err
+	}
+
+	res.addRef(name)
+	res.Summary = fmt.Sprintf("Created %s tag %s", kind, name)
+	return res, nil
+}
+
+// UnpublishedTags lists local tags the remote does not have (needs network)
+func UnpublishedTags() ([]TagInfo, error) {
+	remote := config.Load().Remote
+	if !system.GitOK("remote", "get-url", remote) {
+		return nil, ErrNoRemote
+	}
+
+	args := []string{"ls-remote", "--tags", "--refs", remote}
+	out, err := system.GitCombined(args...)
+	if err != nil {
+		return nil, gitErr(err, out, args...)
+	}
+
+	published := map[string]bool{}
+	for _, l := range splitLines(out) {
+		if _, ref, ok := strings.Cut(l, "\t"); ok {
+			published[strings.TrimPrefix(ref, "refs/tags/")] = true
+		}
+	}
+
+	tags, err := ListTags()
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []TagInfo
+	for _, t := range tags {
+		if !published[t.Name] {
+			missing = append(missing, t)
+		}
+	}
+	return missing, nil
+}
+
+// TagSigningDefault reports git config tag.gpgSign
+func TagSigningDefault() bool {
+	out, err := system.GitRaw("config", "--bool", "tag.gpgSign")
+	return err == nil && strings.TrimSpace(out) == "true"
+}
+
+// RemoteURL is the URL of the configured remote ("" when missing)
+func RemoteURL() string {
+	out, err := system.GitRaw("remote", "get-url", config.Load().Remote)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
+
+/*
+CommitsNotOnRemote counts commits reachable from rev that the remote
+does not have yet – pushing a tag publishes them too
+*/
+func CommitsNotOnRemote(rev string) int {
+	out, err := system.GitRaw("rev-list", "--count", rev, "--not", "--remotes="+config.Load().Remote)
+	if err != nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(strings.TrimSpace(out))
+	return n
+}
+
+/*
+PushTags publishes the named tags to the configured remote
+Commits they would publish pass the secret scan and the large file
+check first (ErrSecretsDetected / ErrLargeFiles, details in Result.Data)
+*/
+func PushTags(names []string) (Result, error) {
+	res := newResult("push-tags")
+
+	if len(names) == 0 {
+		return res, ErrInvalidArguments
+	}
+
+	remote := config.Load().Remote
+	if !system.GitOK("remote", "get-url", remote) {
+		return res, ErrNoRemote
+	}
+
+	sorted := append([]string(nil), names...)
+	sort.Strings(sorted)
+	var refs []string
+	for _, n := range sorted {
+		refs = append(refs, "refs/tags/"+n)
+	}
+
+	if err := res.checkOutgoing(remote, refs...); err != nil {
+		return res, err
+	}
+
+	if err := res.git(append([]string{"push", remote}, refs...)...); err != nil {
+		return res, err
+	}
+
+	res.addRef(sorted...)
+	res.Summary = fmt.Sprintf("Published %d tag(s) to %s", len(sorted), remote)
+	return res, nil
+}
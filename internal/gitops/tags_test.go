@@ -0,0 +1,53 @@
+package gitops
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestPushTagsChecksOutgoing(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want error
+	}{
+		{"secret in a tagged commit", "token := \"ghp_" + "abcdefghijklmnopqrstuvwxyz0123456789\"\n", ErrSecretsDetected},
+		{"clean", "package main\n", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			write, git, branch := branchRepo(t)
+
+			remote := t.TempDir()
+			git("init", "-q", "--bare", remote)
+			git("remote", "add", "origin", remote)
+			git("push", "-q", "origin", branch)
+
+			git("tag", "v1.0.0")
+			write("main.go", tt.data)
+			git("add", ".")
+			git("commit", "-q", "-m", "release work")
+			git("tag", "v1.1.0")
+
+			names := []string{"v1.1.0", "v1.0.0"}
+			_, err := PushTags(names)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("PushTags() error = %v, want %v", err, tt.want)
+			}
+			if names[0] != "v1.1.0" {
+				t.Errorf("PushTags reordered the caller's slice: %q", names)
+			}
+
+			got := strings.Count(git("ls-remote", "--tags", remote), "refs/tags/")
+			want := 2
+			if tt.want != nil {
+				want = 0
+			}
+			if got != want {
+				t.Errorf("%d tag(s) on the remote, want %d", got, want)
+			}
+		})
+	}
+}
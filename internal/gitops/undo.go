@@ -2,32 +2,34 @@ package gitops
 
 import (
 	"git-genius/internal/system"
-	"git-genius/internal/ui"
 )
 
 /*
 UndoLastCommit undoes the last commit but keeps changes staged
 Uses: git reset --soft HEAD~1
+Frontends confirm with the user before calling
 */
-func UndoLastCommit() {
-	if !system.EnsureGitRepo() {
-		return
+func UndoLastCommit() (Result, error) {
+	return journaled("undo", undoLastCommit)
+}
+
+func undoLastCommit() (Result, error) {
+	res := newResult("undo")
+
+	if !system.IsGitRepo() {
+		return res, ErrNotRepo
 	}
 
 	if !hasAnyCommit() {
-		ui.Warn("No commits found to undo")
-		return
+		return res, ErrNoCommits
 	}
 
-	if !ui.Confirm("Undo last commit? (changes will be kept)") {
-		ui.Warn("Undo cancelled")
-		return
-	}
+	res.addRef(CurrentBranch())
 
-	if err := system.RunGit("reset", "--soft", "HEAD~1"); err != nil {
-		ui.Error("Failed to undo last commit")
-		return
+	if err := res.git("reset", "--soft", "HEAD~1"); err != nil {
+		return res, err
 	}
 
-	ui.Success("Last commit undone (changes preserved)")
+	res.Summary = "Last commit undone (changes preserved)"
+	return res, nil
 }
@@ -0,0 +1,223 @@
+package menu
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"git-genius/internal/gitops"
+	"git-genius/internal/picker"
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+/* ============================================================
+   History & Blame (code archaeology)
+   ============================================================ */
+
+// blamePageSize = file lines per blame page
+const blamePageSize = 20
+
+func historyMenu() {
+	if !system.EnsureGitRepo() {
+		return
+	}
+
+	for {
+		ui.Clear()
+		ui.Header("History & Blame")
+
+		fmt.Println("1) Commit history (graph, filters)")
+		fmt.Println("2) Blame a file (who changed each line)")
+		fmt.Println("3) File history (follows renames)")
+		fmt.Println("4) Back")
+		fmt.Println()
+		fmt.Println("Tip: h = help")
+
+		switch ui.Input("Select option") {
+		case "1":
+			browseHistory("History", false)
+		case "2":
+			if path, ok := picker.ProjectFile("File to blame"); ok {
+				blameView(path)
+			}
+		case "3":
+			if path, ok := picker.ProjectFile("File"); ok {
+				fileHistoryView(path)
+			}
+		case "4":
+			return
+		case "h", "help", "?":
+			sectionHelp("History & Blame", ui.HelpHistory)
+		default:
+			ui.Error("Invalid option")
+			ui.Pause()
+		}
+	}
+}
+
+/*
+blameView shows the file with the commit that last changed every line
+
+Commands:
+
+	<line>     open the commit that introduced that line
+	g <line>   go to line
+	n / p      next / previous page
+	h          file history
+	q          back
+*/
+func blameView(path string) {
+	ui.Info("Reading blame for " + path + "...")
+	lines, err := gitops.ReadBlame(path)
+	if err != nil {
+		renderError("blame", err)
+		ui.Pause()
+		return
+	}
+	if len(lines) == 0 {
+		ui.Warn("File is empty")
+		ui.Pause()
+		return
+	}
+
+	offset := 0
+	for {
+		ui.Clear()
+		ui.Header(fmt.Sprintf("Blame: %s (lines %d-%d of %d)", path,
+			offset+1, min(offset+blamePageSize, len(lines)), len(lines)))
+
+		showBlame(lines[offset:min(offset+blamePageSize, len(lines))])
+		fmt.Fprintln(ui.Output())
+		ui.KeyHint("<line> = open commit · g <line> = go to · n/p = next/prev page · h = file history · q = back")
+
+		cmd := strings.ToLower(strings.TrimSpace(ui.Input("Command")))
+		switch cmd {
+		case "q", "back":
+			return
+		case "n", "":
+			if offset+blamePageSize < len(lines) {
+				offset += blamePageSize
+			}
+			continue
+		case "p":
+			offset = max(0, offset-blamePageSize)
+			continue
+		case "h":
+			fileHistoryView(path)
+			continue
+		}
+
+		goTo, numText := false, cmd
+		if rest, ok := strings.CutPrefix(cmd, "g "); ok {
+			goTo, numText = true, strings.TrimSpace(rest)
+		}
+
+		n, err := strconv.Atoi(numText)
+		if err != nil || n < 1 || n > len(lines) {
+			ui.Error("Invalid command: " + cmd)
+			ui.Pause()
+			continue
+		}
+
+		if goTo {
+			offset = (n - 1) / blamePageSize * blamePageSize
+			continue
+		}
+
+		l := lines[n-1]
+		if !l.Committed() {
+			ui.Warn(fmt.Sprintf("Line %d is not committed yet", n))
+			ui.Pause()
+			continue
+		}
+		if l.OrigPath != path {
+			ui.Info(fmt.Sprintf("Line %d came from %s (line %d)", n, l.OrigPath, l.OrigLine))
+		}
+		commitView(l.Hash)
+	}
+}
+
+// showBlame prints commit info only where it changes (like tig / GitHub)
+func showBlame(lines []gitops.BlameLine) {
+	prev := ""
+	for _, l := range lines {
+		info := strings.Repeat(" ", 31)
+		switch {
+		case l.Hash == prev:
+		case !l.Committed():
+			info = fmt.Sprintf("%s%-31s%s", ui.Magenta, "not committed yet", ui.Reset)
+		default:
+			info = fmt.Sprintf("%s%s%s %-12.12s %s", ui.Yellow, shortHash(l.Hash), ui.Reset,
+				l.Author, l.When.Format("2006-01-02"))
+		}
+		prev = l.Hash
+
+		fmt.Fprintf(ui.Output(), "%5d %s │ %s\n", l.Line, info, l.Text)
+	}
+}
+
+/*
+fileHistoryView lists the commits that changed path (renames followed)
+
+Commands:  <n> open commit · d <n> diff of the file in that commit · q back
+*/
+func fileHistoryView(path string) {
+	revs, err := gitops.ReadFileHistory(path)
+	if err != nil {
+		renderError("file-history", err)
+		ui.Pause()
+		return
+	}
+	if len(revs) == 0 {
+		ui.Warn("No commits found for " + path)
+		ui.Pause()
+		return
+	}
+
+	for {
+		ui.Clear()
+		ui.Header("File History: " + path)
+
+		for i, r := range revs {
+			fmt.Fprintf(ui.Output(), " %3d) %s%s%s %-9s %s  %s(%s, %s)%s\n",
+				i+1, ui.Yellow, shortHash(r.Hash), ui.Reset, r.Status, r.Subject,
+				ui.Blue, r.Author, ago(r.When), ui.Reset)
+			if r.OldPath != "" {
+				fmt.Fprintf(ui.Output(), "       %s%s → %s%s\n", ui.Cyan, r.OldPath, r.Path, ui.Reset)
+			}
+		}
+		fmt.Fprintln(ui.Output())
+		ui.KeyHint("<n> = open commit · d <n> = diff of this file · q = back")
+
+		cmd := strings.ToLower(strings.TrimSpace(ui.Input("Command")))
+		if cmd == "q" || cmd == "" {
+			return
+		}
+
+		diff, numText := false, cmd
+		if rest, ok := strings.CutPrefix(cmd, "d "); ok {
+			diff, numText = true, strings.TrimSpace(rest)
+		}
+
+		n, err := strconv.Atoi(numText)
+		if err != nil || n < 1 || n > len(revs) {
+			ui.Error("Invalid command: " + cmd)
+			ui.Pause()
+			continue
+		}
+
+		r := revs[n-1]
+		if !diff {
+			commitView(r.Hash)
+			continue
+		}
+
+		req := gitops.CommitChanges(r.CommitSummary)
+		req.Paths = []string{r.Path}
+		if r.OldPath != "" {
+			req.Paths = append(req.Paths, r.OldPath)
+		}
+		diffViewer(r.Path+" @ "+shortHash(r.Hash), req)
+	}
+}
@@ -0,0 +1,218 @@
+package menu
+
+import (
+	"errors"
+	"fmt"
+
+	"git-genius/internal/config"
+	"git-genius/internal/gitops"
+	"git-genius/internal/ui"
+)
+
+/* ============================================================
+   Branch Manager
+   ============================================================ */
+
+func branchManager() {
+	for {
+		ui.Clear()
+		ui.Header("Branch Manager")
+
+		branches, err := gitops.ListBranches()
+		if err != nil {
+			ui.Error("Could not read branches")
+			ui.Pause()
+			return
+		}
+		showBranches(branches)
+
+		fmt.Println("1) Switch to branch")
+		fmt.Println("2) Rename branch")
+		fmt.Println("3) Delete branch")
+		fmt.Println("4) Set upstream")
+		fmt.Println("5) Clean up merged branches")
+		fmt.Println("6) Back")
+		fmt.Println()
+		fmt.Println("Tip: h = help")
+
+		switch ui.Input("Select option") {
+		case "1":
+			if b, ok := pickBranch("Switch to", branches, true); ok {
+				run(func() (gitops.Result, error) { return SwitchFlow(b.Name, gitops.DirtyRefuse) })
+			}
+		case "2":
+			if b, ok := pickBranch("Rename", branches, false); ok {
+				name := ui.Input("New name for " + b.Name)
+				run(func() (gitops.Result, error) { return gitops.RenameBranch(b.Name, name) })
+			}
+		case "3":
+			if b, ok := pickBranch("Delete", branches, false); ok {
+				run(func() (gitops.Result, error) { return deleteBranchFlow(b) })
+			}
+		case "4":
+			if b, ok := pickBranch("Set upstream for", branches, false); ok {
+				run(func() (gitops.Result, error) { return upstreamFlow(b) })
+			}
+		case "5":
+			run(cleanupFlow)
+		case "6":
+			return
+		case "h", "help", "?":
+			sectionHelp("Branch Manager", ui.HelpBranchManager)
+			continue
+		default:
+			ui.Error("Invalid option")
+		}
+		ui.Pause()
+	}
+}
+
+// showBranches prints local and remote branches as a table
+func showBranches(branches []gitops.BranchInfo) {
+	cfg := config.Load()
+	header := true
+
+	fmt.Println(ui.Bold + "Local" + ui.Reset + "  (merged = into " + cfg.DefaultBranch + ")")
+	for _, b := range branches {
+		if b.Remote && header {
+			fmt.Println()
+			fmt.Println(ui.Bold + "Remote" + ui.Reset)
+			header = false
+		}
+
+		mark := "  "
+		if b.Current {
+			mark = ui.Green + "* " + ui.Reset
+		}
+
+		fmt.Printf("%s%-24s %-9s %-24s %s\n", mark, b.Name, ago(b.LastCommit), branchTracking(b), branchFlags(b, cfg))
+	}
+	fmt.Println()
+}
+
+func branchTracking(b gitops.BranchInfo) string {
+	switch {
+	case b.Remote:
+		return ""
+	case b.Gone:
+		return b.Upstream + " (gone)"
+	case b.Upstream == "":
+		return "-"
+	}
+
+	track := b.Upstream
+	if b.Ahead > 0 {
+		track += fmt.Sprintf(" ↑%d", b.Ahead)
+	}
+	if b.Behind > 0 {
+		track += fmt.Sprintf(" ↓%d", b.Behind)
+	}
+	return track
+}
+
+func branchFlags(b gitops.BranchInfo, cfg config.Config) string {
+	switch {
+	case !b.Remote && b.Name == cfg.DefaultBranch:
+		return ui.Cyan + "default" + ui.Reset
+	case b.Merged:
+		return ui.Green + "merged" + ui.Reset
+	}
+	return ""
+}
+
+// pickBranch lets the user choose a branch (remote ones only when withRemote)
+func pickBranch(action string, branches []gitops.BranchInfo, withRemote bool) (gitops.BranchInfo, bool) {
+	var choices []gitops.BranchInfo
+	var options []string
+	for _, b := range branches {
+		if b.Remote && !withRemote {
+			continue
+		}
+		choices = append(choices, b)
+		options = append(options, b.Name)
+	}
+	options = append(options, "Back")
+
+	choice := ui.Select(action+" which branch?", options)
+	if choice < 1 || choice > len(choices) {
+		return gitops.BranchInfo{}, false
+	}
+	return choices[choice-1], true
+}
+
+// deleteBranchFlow confirms, and asks again for unmerged branches
+func deleteBranchFlow(b gitops.BranchInfo) (gitops.Result, error) {
+	if !ui.Confirm("Delete branch " + b.Name + "?") {
+		return gitops.Result{Operation: "delete-branch"}, gitops.ErrCancelled
+	}
+
+	res, err := gitops.DeleteBranch(b.Name, false)
+	if !errors.Is(err, gitops.ErrNotMerged) {
+		return res, err
+	}
+
+	ui.Warn(b.Name + " has commits that are not in " + config.Load().DefaultBranch)
+	if b.Upstream != "" && !b.Gone && b.Ahead == 0 {
+		ui.Info("They still exist on " + b.Upstream)
+	} else {
+		ui.Info("Deleting loses them (Recovery center can still find them for a while)")
+	}
+	if !ui.Confirm("Delete " + b.Name + " anyway?") {
+		return res, gitops.ErrCancelled
+	}
+	return gitops.DeleteBranch(b.Name, true)
+}
+
+// upstreamFlow sets the upstream, publishing the branch when needed
+func upstreamFlow(b gitops.BranchInfo) (gitops.Result, error) {
+	def := config.Load().Remote + "/" + b.Name
+	upstream := ui.Input("Upstream (Enter = " + def + ")")
+	if upstream == "" {
+		upstream = def
+	}
+
+	res, err := gitops.SetUpstream(b.Name, upstream)
+	if !errors.Is(err, gitops.ErrInvalidArguments) || upstream != def {
+		return res, err
+	}
+
+	ui.Warn(def + " does not exist yet")
+	if !ui.Confirm("Push " + b.Name + " and track it?") {
+		return res, gitops.ErrCancelled
+	}
+	return gitops.PublishBranch(b.Name)
+}
+
+// cleanupFlow lets the user pick merged branches to delete
+func cleanupFlow() (gitops.Result, error) {
+	op := gitops.Result{Operation: "cleanup-branches"}
+
+	names, err := gitops.MergedBranches()
+	if err != nil {
+		return op, err
+	}
+	if len(names) == 0 {
+		op.Summary = "No merged branches to clean up"
+		return op, nil
+	}
+
+	items := make([]ui.PickItem, 0, len(names))
+	for _, n := range names {
+		items = append(items, ui.PickItem{Label: n, Value: n, Selected: true})
+	}
+
+	idx, ok := ui.MultiSelect("Merged into "+config.Load().DefaultBranch+" – delete which?", items)
+	if !ok || len(idx) == 0 {
+		return op, gitops.ErrCancelled
+	}
+
+	selected := make([]string, 0, len(idx))
+	for _, i := range idx {
+		selected = append(selected, names[i])
+	}
+
+	if !ui.Confirm(fmt.Sprintf("Delete %d branch(es)?", len(selected))) {
+		return op, gitops.ErrCancelled
+	}
+	return gitops.CleanupBranches(selected)
+}
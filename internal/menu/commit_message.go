@@ -0,0 +1,247 @@
+package menu
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"git-genius/internal/commitmsg"
+	"git-genius/internal/config"
+	"git-genius/internal/ui"
+)
+
+/* ============================================================
+   Commit Message (free text or guided composer)
+   ============================================================ */
+
+/*
+askCommitMessage asks for a message and checks it against the rules
+allowEmpty = first commit ("Initial commit" is used)
+review (optional) is offered as "d" to look at the diff first
+ok = false when the user cancelled
+*/
+func askCommitMessage(allowEmpty bool, review func()) (string, bool) {
+	rules := config.Load().CommitRules
+
+	label := "Commit message (w = guided composer)"
+	if review != nil {
+		label = "Commit message (w = guided composer, d = review diff)"
+	}
+
+	for {
+		var msg string
+		composed := true
+		if rules.Wizard {
+			msg, composed = composeCommitMessage(rules)
+		} else {
+			msg = ui.Input(label)
+			switch {
+			case strings.EqualFold(msg, "w"):
+				msg, composed = composeCommitMessage(rules)
+			case strings.EqualFold(msg, "d") && review != nil:
+				review()
+				continue
+			}
+		}
+		if !composed {
+			return "", false
+		}
+
+		if msg == "" && allowEmpty {
+			return "", true
+		}
+
+		problems := commitmsg.Validate(msg, rules)
+		if len(problems) == 0 {
+			return msg, true
+		}
+
+		for _, p := range problems {
+			ui.Warn(p)
+		}
+
+		options := []string{"Edit message", "Commit anyway", "Cancel"}
+		if rules.Enforcement == config.EnforceBlock {
+			options = []string{"Edit message", "Cancel"}
+		}
+
+		// no answer (EOF / end of script) = cancel, never "edit" again
+		choice := ui.Select("Message does not follow the rules", options)
+		if choice < 1 {
+			return "", false
+		}
+
+		switch options[choice-1] {
+		case "Edit message":
+			continue
+		case "Commit anyway":
+			return msg, true
+		default:
+			return "", false
+		}
+	}
+}
+
+/*
+composeCommitMessage guides the user through a Conventional Commit:
+type → scope → breaking → subject → body → issue refs → preview
+ok = false when no type or no description was given
+*/
+func composeCommitMessage(rules config.CommitRules) (string, bool) {
+	ui.Header("Commit Message Composer")
+
+	var m commitmsg.Message
+
+	labels := make([]string, len(rules.AllowedTypes))
+	for i, t := range rules.AllowedTypes {
+		labels[i] = t
+		if d, ok := commitmsg.TypeDescriptions[t]; ok {
+			labels[i] = fmt.Sprintf("%-9s %s", t, d)
+		}
+	}
+	choice := ui.Select("Type of change", labels)
+	if choice < 1 {
+		return "", false
+	}
+	m.Type = rules.AllowedTypes[choice-1]
+
+	m.Scope = ui.Input("Scope (optional, e.g. api, ui)")
+	m.Breaking = ui.Confirm("Is this a BREAKING change?")
+
+	prompt := "Short description (Enter = cancel)"
+	if rules.MaxSubjectLength > 0 {
+		limit := rules.MaxSubjectLength - len(m.Header())
+		prompt = "Short description (" + strconv.Itoa(limit) + " chars left, Enter = cancel)"
+	}
+	m.Subject = strings.TrimSpace(ui.Input(prompt))
+	if m.Subject == "" {
+		ui.Warn("No description, composer cancelled")
+		return "", false
+	}
+
+	ui.Info("Longer explanation (optional, empty line to finish)")
+	var body []string
+	for {
+		line := ui.Input(">")
+		if line == "" {
+			break
+		}
+		body = append(body, line)
+	}
+	m.Body = strings.Join(body, "\n")
+
+	if m.Breaking {
+		if what := ui.Input("What breaks? (migration notes)"); what != "" {
+			m.Footers = append(m.Footers, "BREAKING CHANGE: "+what)
+		}
+	}
+
+	if refs := ui.Input("Issue / ticket references (e.g. #12, ABC-123, optional)"); refs != "" {
+		m.Footers = append(m.Footers, "Refs: "+refs)
+	}
+
+	msg := m.Format()
+
+	ui.Divider()
+	fmt.Fprintln(ui.Output(), msg)
+	ui.Divider()
+
+	return msg, true
+}
+
+/* ============================================================
+   Commit Message Rules (Tools)
+   ============================================================ */
+
+func commitRulesMenu() {
+	for {
+		cfg := config.Load()
+		r := cfg.CommitRules
+
+		ui.Clear()
+		ui.Header("Commit Message Rules")
+
+		ticket := r.TicketPattern
+		if ticket == "" {
+			ticket = "(not required)"
+		}
+
+		maxLen := strconv.Itoa(r.MaxSubjectLength)
+		if r.MaxSubjectLength < 0 {
+			maxLen = "off"
+		}
+
+		ui.PrintKV("Enforce", r.Enforcement)
+		ui.PrintKV("Conv.", onOff(r.Conventional)+"  (type(scope): subject)")
+		ui.PrintKV("Types", strings.Join(r.AllowedTypes, ", "))
+		ui.PrintKV("Max len", maxLen)
+		ui.PrintKV("Ticket", ticket)
+		ui.PrintKV("Composer", onOff(r.Wizard)+"  (always use guided composer)")
+		fmt.Println()
+
+		fmt.Println("1) Enforcement (off / warn / block)")
+		fmt.Println("2) Toggle Conventional Commits")
+		fmt.Println("3) Allowed types")
+		fmt.Println("4) Max first-line length")
+		fmt.Println("5) Required ticket pattern")
+		fmt.Println("6) Toggle guided composer")
+		fmt.Println("7) Back")
+		fmt.Println()
+
+		switch ui.Input("Select option") {
+		case "1":
+			levels := []string{config.EnforceOff, config.EnforceWarn, config.EnforceBlock}
+			if c := ui.Select("Enforcement", levels); c > 0 {
+				r.Enforcement = levels[c-1]
+			}
+		case "2":
+			r.Conventional = !r.Conventional
+		case "3":
+			if types := ui.Input("Types, comma separated (empty = defaults)"); types != "" {
+				r.AllowedTypes = splitList(types)
+			} else {
+				r.AllowedTypes = nil
+			}
+		case "4":
+			switch n, err := strconv.Atoi(ui.Input("Max length (0 = off)")); {
+			case err != nil || n < 0:
+				ui.Error("Enter a number, 0 turns the rule off")
+				ui.Pause()
+			case n == 0:
+				r.MaxSubjectLength = -1
+			default:
+				r.MaxSubjectLength = n
+			}
+		case "5":
+			r.TicketPattern = ui.Input("Regexp (e.g. [A-Z]+-[0-9]+ or #[0-9]+, empty = none)")
+		case "6":
+			r.Wizard = !r.Wizard
+		case "7":
+			return
+		default:
+			ui.Error("Invalid option")
+			ui.Pause()
+			continue
+		}
+
+		cfg.CommitRules = r
+		config.Save(cfg)
+	}
+}
+
+func onOff(b bool) string {
+	if b {
+		return "on"
+	}
+	return "off"
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, part := range strings.Split(s, ",") {
+		if p := strings.TrimSpace(part); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
@@ -0,0 +1,61 @@
+package menu
+
+import (
+	"testing"
+
+	"git-genius/internal/config"
+)
+
+func TestAskCommitMessage(t *testing.T) {
+	conventional := config.CommitRules{Enforcement: config.EnforceBlock, Conventional: true}
+	warn := config.CommitRules{Enforcement: config.EnforceWarn, Conventional: true}
+
+	tests := []struct {
+		name    string
+		rules   config.CommitRules
+		answers []string
+		want    string
+		ok      bool
+	}{
+		{"free text", config.CommitRules{}, []string{"fix: typo"}, "fix: typo", true},
+		{"edit after a rule problem", conventional, []string{"typo", "1", "fix: typo"}, "fix: typo", true},
+		{"commit anyway (warn)", warn, []string{"typo", "2"}, "typo", true},
+		{"cancel after a rule problem", conventional, []string{"typo", "2"}, "", false},
+		{"script ends at the rule problem", conventional, []string{"typo"}, "", false},
+		{"script ends before any message", conventional, nil, "", false},
+		{
+			name:    "guided composer",
+			rules:   conventional,
+			answers: []string{"w", "2", "api", "n", "handle nil", "", ""},
+			want:    "fix(api): handle nil",
+			ok:      true,
+		},
+		{
+			name:    "guided composer with breaking change",
+			rules:   conventional,
+			answers: []string{"w", "1", "", "y", "new config format", "Old keys are gone.", "", "rename keys", "#12"},
+			want:    "feat!: new config format\n\nOld keys are gone.\n\nBREAKING CHANGE: rename keys\nRefs: #12",
+			ok:      true,
+		},
+		{"composer without a type", conventional, []string{"w"}, "", false},
+		{"composer without a description", conventional, []string{"w", "2", "", "n", ""}, "", false},
+		{"wizard rule, script ends", config.CommitRules{Enforcement: config.EnforceWarn, Wizard: true}, nil, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, prompter, _ := scripted(t, tt.answers...)
+			cfg := config.Load()
+			cfg.CommitRules = tt.rules
+			config.Save(cfg)
+
+			got, ok := askCommitMessage(false, nil)
+			if got != tt.want || ok != tt.ok {
+				t.Errorf("askCommitMessage() = %q, %v; want %q, %v", got, ok, tt.want, tt.ok)
+			}
+			if left := prompter.Remaining(); len(left) > 0 {
+				t.Errorf("unused answers: %q", left)
+			}
+		})
+	}
+}
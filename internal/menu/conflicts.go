@@ -0,0 +1,227 @@
+package menu
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"git-genius/internal/gitops"
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+/* ============================================================
+   Merge + Conflict Assistant
+   ============================================================ */
+
+// MergeFlow picks a branch and merges it into the current one
+func MergeFlow() (gitops.Result, error) {
+	op := gitops.Result{Operation: "merge"}
+
+	branches, err := gitops.ListBranches()
+	if err != nil {
+		return op, err
+	}
+
+	var names []string
+	for _, b := range branches {
+		if !b.Current {
+			names = append(names, b.Name)
+		}
+	}
+	if len(names) == 0 {
+		op.Summary = "No other branch to merge"
+		return op, nil
+	}
+
+	choice := ui.Select("Merge which branch into "+gitops.CurrentBranch()+"?", append(names, "Back"))
+	if choice < 1 || choice > len(names) {
+		return op, gitops.ErrCancelled
+	}
+
+	noFF := ui.Confirm("Always create a merge commit (--no-ff)?")
+	ui.Info("Merging " + names[choice-1] + "...")
+	return gitops.Merge(names[choice-1], noFF)
+}
+
+// offerConflictAssistant is shown after any operation stopped by conflicts
+func offerConflictAssistant() {
+	if len(gitops.ConflictedPaths()) == 0 {
+		return
+	}
+	if ui.ConfirmDefault("Open the conflict assistant now?", true) {
+		conflictAssistant()
+	}
+}
+
+func conflictAssistant() {
+	for {
+		ui.Clear()
+		ui.Header("Conflict Assistant")
+
+		paths := gitops.ConflictedPaths()
+		pending, merging := gitops.PendingOperation()
+
+		if len(paths) == 0 && !merging {
+			ui.Success("No conflicts")
+			return
+		}
+
+		options := make([]string, 0, len(paths)+3)
+		for _, p := range paths {
+			options = append(options, p+conflictCount(p))
+		}
+
+		complete := len(options) + 1
+		if merging {
+			options = append(options, "Continue "+pending.Kind)
+		} else {
+			options = append(options, "Done (keep resolutions uncommitted)")
+		}
+		options = append(options, "Abort (back to the state before)", "Back")
+
+		if len(paths) > 0 {
+			ui.Warn(fmt.Sprintf("%d file(s) with conflicts", len(paths)))
+		} else {
+			ui.Success("All conflicts resolved")
+		}
+
+		choice := ui.Select("Pick a file to resolve, or finish", options)
+		switch {
+		case choice >= 1 && choice <= len(paths):
+			resolveFile(paths[choice-1])
+		case choice == complete:
+			res, err := gitops.CompleteMerge()
+			Render(res, err)
+			if err == nil {
+				ui.Pause()
+				return
+			}
+		case choice == complete+1:
+			if ui.Confirm("Abort and throw away the merge result?") {
+				Render(gitops.AbortMerge())
+				ui.Pause()
+				return
+			}
+			continue
+		default:
+			return
+		}
+		ui.Pause()
+	}
+}
+
+func conflictCount(path string) string {
+	cf, err := gitops.ReadConflicts(path)
+	switch {
+	case errors.Is(err, gitops.ErrBinaryConflict):
+		return "  (binary)"
+	case err != nil || len(cf.Conflicts) == 0:
+		return "  (whole file)"
+	}
+	return fmt.Sprintf("  (%d conflict(s))", len(cf.Conflicts))
+}
+
+// resolveFile walks through every conflict region of path
+func resolveFile(path string) {
+	cf, err := gitops.ReadConflicts(path)
+	if err != nil || len(cf.Conflicts) == 0 {
+		resolveWholeFile(path)
+		return
+	}
+
+	for i, c := range cf.Conflicts {
+		ui.Divider()
+		fmt.Printf("%sConflict %d/%d in %s%s\n", ui.Bold, i+1, len(cf.Conflicts), path, ui.Reset)
+		printSide(ui.Green, "ours   ("+c.OursLabel+")", c.Ours)
+		if len(c.Base) > 0 {
+			printSide(ui.Yellow, "base", c.Base)
+		}
+		printSide(ui.Cyan, "theirs ("+c.TheirsLabel+")", c.Theirs)
+
+		switch pickConflict() {
+		case "o":
+			c.Pick = gitops.PickOurs
+		case "t":
+			c.Pick = gitops.PickTheirs
+		case "b":
+			c.Pick = gitops.PickBoth
+		case "e":
+			editConflicted(path)
+			return
+		case "q":
+			saveResolution(cf)
+			return
+		}
+	}
+
+	saveResolution(cf)
+}
+
+func pickConflict() string {
+	for {
+		switch a := strings.ToLower(ui.Input("[o]urs, [t]heirs, [b]oth, [e]ditor, [s]kip, [q]uit")); a {
+		case "o", "t", "b", "e", "s", "q":
+			return a
+		case "":
+			return "s"
+		}
+		ui.Error("Invalid choice")
+	}
+}
+
+func printSide(color, label string, lines []string) {
+	fmt.Println(color + "── " + label + ui.Reset)
+	if len(lines) == 0 {
+		fmt.Println(color + "   (nothing)" + ui.Reset)
+	}
+	for _, l := range lines {
+		fmt.Println(color + "│ " + ui.Reset + strings.TrimRight(l, "\r\n"))
+	}
+}
+
+func saveResolution(cf gitops.ConflictFile) {
+	if len(cf.Conflicts) == cf.Unresolved() {
+		return
+	}
+	Render(gitops.WriteResolution(cf))
+}
+
+// resolveWholeFile handles binary files and modify/delete conflicts
+func resolveWholeFile(path string) {
+	ui.Warn(path + " cannot be resolved region by region")
+
+	switch ui.Select("Resolve "+path+" with", []string{
+		"Our version (current branch)",
+		"Their version (incoming)",
+		"Open in editor",
+		"Mark as resolved as it is now",
+		"Back",
+	}) {
+	case 1:
+		Render(gitops.TakeSide(path, gitops.PickOurs))
+	case 2:
+		Render(gitops.TakeSide(path, gitops.PickTheirs))
+	case 3:
+		editConflicted(path)
+	case 4:
+		Render(gitops.MarkResolved(path))
+	}
+}
+
+// editConflicted opens $EDITOR, then offers to mark the file resolved
+func editConflicted(path string) {
+	ui.Info("Opening " + system.Editor() + "...")
+	if err := system.OpenEditor(path); err != nil {
+		ui.Error("Editor failed: " + err.Error())
+		return
+	}
+
+	if gitops.HasConflictMarkers(path) {
+		ui.Warn(path + " still contains conflict markers")
+		return
+	}
+	if ui.ConfirmDefault("Mark "+path+" as resolved?", true) {
+		Render(gitops.MarkResolved(path))
+	}
+}
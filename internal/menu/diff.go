@@ -0,0 +1,200 @@
+package menu
+
+import (
+	"fmt"
+	"strings"
+
+	"git-genius/internal/diffview"
+	"git-genius/internal/gitops"
+	"git-genius/internal/ui"
+)
+
+/* ============================================================
+   Diff Viewer (file by file, unified or side by side)
+   ============================================================ */
+
+// diffOpts is kept for the session (s / w toggles)
+var diffOpts = diffview.Options{Words: true}
+
+// SetDiffLayout chooses side by side (true) or unified output
+func SetDiffLayout(sideBySide bool) {
+	diffOpts.SideBySide = sideBySide
+}
+
+func diffMenu() {
+	for {
+		ui.Clear()
+		ui.Header("Review Changes")
+
+		fmt.Println("1) Not staged yet (work tree)")
+		fmt.Println("2) Staged (ready to commit)")
+		fmt.Println("3) Everything uncommitted")
+		fmt.Println("4) Compare two commits / branches / tags")
+		fmt.Println("5) Back")
+		fmt.Println()
+
+		switch ui.Input("Select option") {
+		case "1":
+			diffViewer("Work Tree Changes", gitops.DiffRequest{Source: gitops.DiffWorktree})
+		case "2":
+			diffViewer("Staged Changes", gitops.DiffRequest{Source: gitops.DiffStaged})
+		case "3":
+			diffViewer("Uncommitted Changes", gitops.DiffRequest{Source: gitops.DiffHead})
+		case "4":
+			if req, ok := askCompare(); ok {
+				diffViewer("Compare "+shortRef(req.From)+" → "+shortRef(req.To), req)
+			}
+		case "5", "q":
+			return
+		default:
+			ui.Error("Invalid option")
+			ui.Pause()
+		}
+	}
+}
+
+// askCompare reads two revisions (Enter = pick from history)
+func askCompare() (gitops.DiffRequest, bool) {
+	req := gitops.DiffRequest{Source: gitops.DiffCommits}
+
+	pick := func(label string) (string, bool) {
+		rev := strings.TrimSpace(ui.Input(label + " (commit, branch or tag; Enter = pick from history)"))
+		if rev != "" {
+			return rev, true
+		}
+		c, ok := PickCommit("Compare: " + strings.ToLower(label))
+		return c.Hash, ok
+	}
+
+	var ok bool
+	if req.From, ok = pick("From (older)"); !ok {
+		return req, false
+	}
+	if req.To, ok = pick("To (newer)"); !ok {
+		return req, false
+	}
+	return req, true
+}
+
+func shortRef(rev string) string {
+	if len(rev) == 40 || len(rev) == 64 {
+		return shortHash(rev)
+	}
+	return rev
+}
+
+/*
+diffViewer lists the changed files of req and opens them one by one
+
+Commands (file list):  <n> open · s side by side · w word highlight · q back
+Commands (file view):  n / p next / previous file · s · w · l / q file list
+*/
+func diffViewer(title string, req gitops.DiffRequest) {
+	files, err := gitops.ReadDiff(req)
+	if err != nil {
+		renderError("diff", err)
+		ui.Pause()
+		return
+	}
+	if len(files) == 0 {
+		ui.Info("No changes")
+		ui.Pause()
+		return
+	}
+
+	for {
+		ui.Clear()
+		ui.Header(title)
+		showDiffFiles(files)
+		fmt.Fprintln(ui.Output())
+		ui.KeyHint("<n> = open · Enter = first file · " + layoutHint() + " · q = back")
+
+		cmd := strings.ToLower(strings.TrimSpace(ui.Input("Command")))
+		switch cmd {
+		case "q", "back":
+			return
+		case "s", "w":
+			toggleDiffOption(cmd)
+			continue
+		case "":
+			cmd = "1"
+		}
+
+		var n int
+		if _, err := fmt.Sscan(cmd, &n); err != nil || n < 1 || n > len(files) {
+			ui.Error("Invalid command: " + cmd)
+			ui.Pause()
+			continue
+		}
+		diffFileView(title, files, n-1)
+	}
+}
+
+func showDiffFiles(files []gitops.FileDiff) {
+	for i, f := range files {
+		added, deleted := f.Stats()
+		name := f.Path
+		if f.OldPath != "" {
+			name = f.OldPath + " → " + f.Path
+		}
+		change := fmt.Sprintf("%s+%d%s %s-%d%s", ui.Green, added, ui.Reset, ui.Red, deleted, ui.Reset)
+		if f.Binary {
+			change = ui.Yellow + "binary" + ui.Reset
+		}
+		fmt.Fprintf(ui.Output(), " %2d) %-9s %s  %s\n", i+1, f.Status, name, change)
+	}
+}
+
+// diffFileView shows files[i] and moves between files until l / q
+func diffFileView(title string, files []gitops.FileDiff, i int) {
+	for {
+		ui.Clear()
+		ui.Header(fmt.Sprintf("%s (%d/%d)", title, i+1, len(files)))
+		diffview.Render(ui.Output(), files[i], diffOpts)
+		fmt.Fprintln(ui.Output())
+		ui.KeyHint("n = next file · p = previous · " + layoutHint() + " · l / q = file list")
+
+		switch cmd := strings.ToLower(strings.TrimSpace(ui.Input("Command"))); cmd {
+		case "n", "":
+			if i == len(files)-1 {
+				return
+			}
+			i++
+		case "p":
+			i = max(0, i-1)
+		case "s", "w":
+			toggleDiffOption(cmd)
+		case "l", "q":
+			return
+		default:
+			ui.Error("Invalid command: " + cmd)
+			ui.Pause()
+		}
+	}
+}
+
+func layoutHint() string {
+	s := "s = side by side"
+	if diffOpts.SideBySide {
+		s = "s = unified"
+	}
+	w := "w = word highlight on"
+	if diffOpts.Words {
+		w = "w = word highlight off"
+	}
+	return s + " · " + w
+}
+
+func toggleDiffOption(cmd string) {
+	if cmd == "w" {
+		diffOpts.Words = !diffOpts.Words
+		return
+	}
+
+	diffOpts.SideBySide = !diffOpts.SideBySide
+	if width := ui.TerminalWidth(); diffOpts.SideBySide && width < diffview.MinSideBySideWidth {
+		ui.Warn(fmt.Sprintf("Terminal is %d columns wide, side by side needs %d (showing unified)",
+			width, diffview.MinSideBySideWidth))
+		ui.Pause()
+	}
+}
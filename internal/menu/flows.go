@@ -0,0 +1,317 @@
+package menu
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"git-genius/internal/gitops"
+	"git-genius/internal/picker"
+	"git-genius/internal/secrets"
+	"git-genius/internal/ui"
+)
+
+/* ============================================================
+   Interactive Flows (prompts live here, not in gitops)
+   Shared by the menu and the CLI
+   ============================================================ */
+
+// SmartPullFlow asks before auto-stashing, then runs SmartPull
+func SmartPullFlow() (gitops.Result, error) {
+	if !gitops.IsDirty() {
+		ui.Info("Pulling latest changes...")
+		return gitops.SmartPull(false)
+	}
+
+	ui.Warn("Uncommitted changes detected")
+
+	if !ui.Confirm("Auto-stash changes and continue pull?") {
+		return gitops.Result{Operation: "smart-pull"}, gitops.ErrCancelled
+	}
+
+	ui.Info("Pulling latest changes...")
+	return gitops.SmartPull(true)
+}
+
+// UndoFlow confirms before undoing the last commit
+func UndoFlow() (gitops.Result, error) {
+	if !gitops.HasCommits() {
+		return gitops.Result{Operation: "undo"}, gitops.ErrNoCommits
+	}
+
+	if !ui.Confirm("Undo last commit? (changes will be kept)") {
+		return gitops.Result{Operation: "undo"}, gitops.ErrCancelled
+	}
+
+	return gitops.UndoLastCommit()
+}
+
+/*
+SwitchFlow explains what switching to name will do, confirms new branches
+and asks how to handle uncommitted changes (unless dirty is preset)
+*/
+func SwitchFlow(name, dirty string) (gitops.Result, error) {
+	op := gitops.Result{Operation: "switch"}
+
+	target, err := gitops.ResolveBranch(name)
+	if err != nil {
+		return op, err
+	}
+
+	switch target.Kind {
+	case gitops.BranchLocal:
+		ui.Info("Existing local branch: " + target.Name)
+	case gitops.BranchRemote:
+		ui.Info("Branch exists on " + target.Upstream + ": a local tracking branch will be created")
+	default:
+		ui.Info("No branch named " + target.Name + " yet")
+		if !ui.ConfirmDefault("Create new branch "+target.Name+" from the current commit?", true) {
+			return op, gitops.ErrCancelled
+		}
+	}
+
+	if dirty == gitops.DirtyRefuse && gitops.IsDirty() &&
+		!(target.Kind == gitops.BranchLocal && target.Name == gitops.CurrentBranch()) {
+		ui.Warn("You have uncommitted changes")
+
+		switch ui.Select("What should happen to them?", []string{
+			"Stash them (stay with " + gitops.CurrentBranch() + ", restore later)",
+			"Carry them over to " + target.Name,
+			"Abort",
+		}) {
+		case 1:
+			dirty = gitops.DirtyStash
+		case 2:
+			dirty = gitops.DirtyCarry
+		default:
+			return op, gitops.ErrCancelled
+		}
+	}
+
+	return gitops.SwitchBranch(target.Name, dirty)
+}
+
+/*
+UndoOperationFlow shows the last journaled operation and reverts it
+Extra confirmation when the repository changed since then
+*/
+func UndoOperationFlow() (gitops.Result, error) {
+	op := gitops.Result{Operation: "undo-operation"}
+
+	e, ok := gitops.LastOperation()
+	if !ok {
+		return op, gitops.ErrNothingToUndo
+	}
+
+	ui.Divider()
+	ui.PrintKV("Operation", e.Operation)
+	ui.PrintKV("When", e.Time.Format("2006-01-02 15:04:05")+"  ("+ago(e.Time)+")")
+	ui.PrintKV("Branch", journalChange(e.Branch, e.BranchAfter))
+	ui.PrintKV("HEAD", journalChange(shortHash(e.HeadBefore), shortHash(e.HeadAfter)))
+	if n := len(e.StashCreated); n > 0 {
+		ui.PrintKV("Stash", fmt.Sprintf("%d stash(es) will be applied again", n))
+	}
+	if n := len(e.StashRemoved); n > 0 {
+		ui.PrintKV("Stash", fmt.Sprintf("%d removed stash(es) will be restored", n))
+	}
+	if len(e.RefsCreated) > 0 {
+		ui.PrintKV("Delete", refNames(e.RefsCreated))
+	}
+	if len(e.RefsDeleted) > 0 {
+		ui.PrintKV("Restore", refNames(e.RefsDeleted))
+	}
+	if e.WorkTree {
+		ui.PrintKV("Work tree", "staged and unstaged changes reset to before "+e.Operation)
+	}
+	if e.Failed {
+		ui.Warn("This operation failed part-way")
+	}
+	if e.Pushed != "" {
+		ui.Warn("Already pushed to " + e.Pushed + ": only your local copy is reverted")
+	}
+	ui.Divider()
+
+	force := false
+	if e.Diverged() {
+		ui.Warn("The repository changed after this operation")
+		ui.Info("Newer commits / checkouts will be left behind (a backup ref is kept)")
+		if e.WorkTree {
+			ui.Info("Newer file changes are saved to the stash list first")
+		}
+		force = true
+	}
+
+	if !ui.Confirm("Revert to the state before " + e.Operation + "?") {
+		return op, gitops.ErrCancelled
+	}
+	return gitops.UndoOperation(force)
+}
+
+// refNames lists journaled refs without refs/heads/ and refs/tags/
+func refNames(refs map[string]string) string {
+	names := make([]string, 0, len(refs))
+	for ref := range refs {
+		names = append(names, strings.TrimPrefix(strings.TrimPrefix(ref, "refs/heads/"), "refs/tags/"))
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func journalChange(before, after string) string {
+	if before == after {
+		return before
+	}
+	return before + " → " + after
+}
+
+/*
+PushFlow announces the push, then commits the selection and pushes
+Oversized files offer untrack / .gitignore / LFS before retrying
+Secret findings are shown and need a typed override to continue
+*/
+func PushFlow(opts gitops.PushOptions) (gitops.Result, error) {
+	if !gitops.HasCommits() {
+		ui.Info("Creating first commit")
+	} else {
+		ui.Info("Committing and pushing changes...")
+	}
+
+	for {
+		res, err := gitops.Push(opts)
+
+		switch {
+		case errors.Is(err, gitops.ErrLargeFiles):
+			files, _ := res.Data.([]gitops.LargeFile)
+			if !resolveLargeFiles(files, &opts) {
+				return res, err
+			}
+
+		case errors.Is(err, gitops.ErrSecretsDetected) && !opts.AllowSecrets:
+			findings, _ := res.Data.([]secrets.Finding)
+			showFindings(findings)
+
+			if !confirmSecretsOverride() {
+				return res, err
+			}
+			ui.Warn("Secret scan overridden")
+			opts.AllowSecrets = true
+
+		default:
+			return res, err
+		}
+	}
+}
+
+// overrideWord must be typed; --yes never overrides the secret scan
+const overrideWord = "override"
+
+func confirmSecretsOverride() bool {
+	ui.Info("Remove the secret, or add a false positive to " + secrets.AllowlistFile)
+	answer := ui.Input("Type '" + overrideWord + "' to commit/push anyway (Enter = stop)")
+	return strings.EqualFold(strings.TrimSpace(answer), overrideWord)
+}
+
+// showFindings lists secret scan findings (values redacted)
+func showFindings(findings []secrets.Finding) {
+	ui.Divider()
+	ui.Error(fmt.Sprintf("Possible secrets found (%d)", len(findings)))
+
+	for _, f := range findings {
+		where := f.Path
+		if f.Line > 0 {
+			where = fmt.Sprintf("%s:%d", f.Path, f.Line)
+		}
+		if f.Commit != "" {
+			where += " @ " + shortHash(f.Commit)
+		}
+		fmt.Fprintf(ui.Output(), "  %s%-28s%s %s  %s\n", ui.Yellow, f.Description, ui.Reset, where, f.Match)
+	}
+	ui.Divider()
+}
+
+func shortHash(h string) string {
+	if len(h) > 7 {
+		return h[:7]
+	}
+	return h
+}
+
+/*
+InteractivePush lets the user pick the files to commit,
+asks for the commit message, then runs PushFlow
+*/
+func InteractivePush() (gitops.Result, error) {
+	var opts gitops.PushOptions
+
+	st, err := gitops.ReadStatus()
+	if err != nil {
+		return gitops.Result{Operation: "push"}, err
+	}
+
+	if !st.Clean() {
+		paths, ok := picker.Files("Files to commit", st)
+		if !ok {
+			return gitops.Result{Operation: "push"}, gitops.ErrCancelled
+		}
+		if len(paths) == 0 {
+			return gitops.Result{Operation: "push"}, gitops.ErrNothingToCommit
+		}
+		opts.Paths = paths
+
+		if hunkFiles := picker.HunkCandidates(st, paths); len(hunkFiles) > 0 &&
+			ui.Confirm("Choose individual changes (hunks) in modified files?") {
+			if err := stageHunks(paths, hunkFiles); err != nil {
+				return gitops.Result{Operation: "push"}, err
+			}
+			opts.KeepIndex = true
+		}
+	}
+
+	review := func() {
+		req := gitops.DiffRequest{Source: gitops.DiffHead, Paths: opts.Paths}
+		if opts.KeepIndex {
+			req.Source = gitops.DiffStaged
+		}
+		diffViewer("Changes To Commit", req)
+	}
+	if len(opts.Paths) == 0 {
+		review = nil
+	}
+
+	msg, ok := askCommitMessage(!gitops.HasCommits(), review)
+	if !ok {
+		return gitops.Result{Operation: "push"}, gitops.ErrCancelled
+	}
+
+	opts.Message = msg
+	return PushFlow(opts)
+}
+
+/*
+stageHunks stages the selected files, then lets the user review every
+modified file hunk by hunk (git add -p). Files not reached after "q"
+stay unstaged, exactly like git.
+*/
+func stageHunks(paths, hunkFiles []string) error {
+	if _, err := gitops.StagePaths(paths); err != nil {
+		return err
+	}
+
+	for _, p := range hunkFiles {
+		if _, err := gitops.UnstagePath(p); err != nil {
+			return err
+		}
+	}
+
+	for _, p := range hunkFiles {
+		_, quit, err := picker.Hunks(p)
+		if err != nil {
+			return err
+		}
+		if quit {
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package menu
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"git-genius/internal/gitops"
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+/*
+scripted runs the test in an empty directory with canned answers and a
+FakeRunner; the returned buffer holds everything the flow printed
+*/
+func scripted(t *testing.T, answers ...string) (*system.FakeRunner, *ui.ScriptedPrompter, *bytes.Buffer) {
+	t.Helper()
+
+	wd, _ := os.Getwd()
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+
+	fake := system.NewFakeRunner()
+	prompter := ui.NewScriptedPrompter(answers...)
+	var out bytes.Buffer
+
+	prevRunner := system.SetRunner(fake)
+	prevPrompter := ui.SetPrompter(prompter)
+	prevOut := ui.Output()
+	ui.SetOutput(&out)
+
+	t.Cleanup(func() {
+		ui.SetOutput(prevOut)
+		ui.SetPrompter(prevPrompter)
+		system.SetRunner(prevRunner)
+		_ = os.Chdir(wd)
+	})
+	return fake, prompter, &out
+}
+
+// dirtyRepo answers like a repository on main with two modified files and a new one
+func dirtyRepo(fake *system.FakeRunner) {
+	fake.On("status --porcelain=v2 --branch -z --untracked-files=all", system.Response{Stdout: strings.Join([]string{
+		"# branch.oid 1a2b3c4d",
+		"# branch.head main",
+		"1 .M N... 100644 100644 100644 aaa aaa a.go",
+		"1 .M N... 100644 100644 100644 bbb bbb b.go",
+		"? new.txt",
+		"",
+	}, "\x00")})
+	fake.On("status --porcelain", system.Response{Stdout: " M a.go\n M b.go\n?? new.txt\n"})
+	fake.On("branch --show-current", system.Response{Stdout: "main\n"})
+	fake.On("diff --cached --quiet", system.Response{ExitCode: 1})
+}
+
+func hasCommand(cmds []string, want string) bool {
+	for _, c := range cmds {
+		if c == want {
+			return true
+		}
+	}
+	return false
+}
+
+func TestInteractivePush(t *testing.T) {
+	tests := []struct {
+		name    string
+		answers []string
+		err     error
+		want    []string // commands that must run
+		never   []string // commands that must not run
+	}{
+		{
+			name:    "all files",
+			answers: []string{"", "n", "fix: typo"},
+			want:    []string{"add -A -- a.go b.go new.txt", "commit -m fix: typo", "push -u origin main"},
+		},
+		{
+			name:    "one file left out",
+			answers: []string{"2", "", "n", "feat: parser"},
+			want:    []string{"add -A -- a.go new.txt", "commit -m feat: parser", "push -u origin main"},
+		},
+		{
+			name:    "cancelled in the file picker",
+			answers: []string{"q"},
+			err:     gitops.ErrCancelled,
+			never:   []string{"commit", "push"},
+		},
+		{
+			name:    "script ends at the commit message",
+			answers: []string{"", "n"},
+			err:     gitops.ErrCancelled,
+			never:   []string{"commit", "push"},
+		},
+		{
+			name:    "nothing selected",
+			answers: []string{"n", ""},
+			err:     gitops.ErrNothingToCommit,
+			never:   []string{"commit", "push"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake, prompter, _ := scripted(t, tt.answers...)
+			dirtyRepo(fake)
+
+			_, err := InteractivePush()
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("InteractivePush() error = %v, want %v", err, tt.err)
+			}
+
+			cmds := fake.Commands()
+			for _, c := range tt.want {
+				if !hasCommand(cmds, c) {
+					t.Errorf("missing git %s\nran: %q", c, cmds)
+				}
+			}
+			for _, c := range tt.never {
+				for _, ran := range cmds {
+					if ran == c || strings.HasPrefix(ran, c+" ") {
+						t.Errorf("unexpected git %s", ran)
+					}
+				}
+			}
+			if left := prompter.Remaining(); len(left) > 0 {
+				t.Errorf("unused answers: %q", left)
+			}
+		})
+	}
+}
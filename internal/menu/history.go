@@ -0,0 +1,285 @@
+package menu
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"git-genius/internal/gitops"
+	"git-genius/internal/ui"
+)
+
+/* ============================================================
+   History Browser (log + graph + filters)
+   ============================================================ */
+
+// historyPageSize fits a phone screen in portrait mode
+const historyPageSize = 15
+
+/*
+PickCommit opens the history browser in pick mode
+Returns the chosen commit, ok = false when the user backs out
+*/
+func PickCommit(title string) (gitops.CommitSummary, bool) {
+	return browseHistory(title, true)
+}
+
+/*
+browseHistory shows one page at a time
+
+Commands:
+
+	<n>        open commit n (pick mode: choose it)
+	v <n>      view commit n (pick mode)
+	n / p      next / previous page
+	f          filters (author, path, dates, message)
+	a          toggle all branches
+	h          help
+	q          back
+*/
+func browseHistory(title string, pick bool) (gitops.CommitSummary, bool) {
+	var filter gitops.LogFilter
+	offset := 0
+
+	for {
+		ui.Clear()
+		ui.Header(title)
+
+		if !gitops.HasCommits() {
+			ui.Warn("No commits found")
+			ui.Pause()
+			return gitops.CommitSummary{}, false
+		}
+
+		page, err := gitops.ReadLog(filter, offset, historyPageSize)
+		if err != nil {
+			renderError("log", err)
+			ui.Pause()
+			return gitops.CommitSummary{}, false
+		}
+		commits := page.Commits()
+
+		showFilter(filter)
+		showLogPage(page, offset)
+
+		hint := "<n> = open · n/p = next/prev page · f = filter · a = all branches · h = help · q = back"
+		if pick {
+			hint = "<n> = choose · v <n> = view · n/p = next/prev page · f = filter · a = all branches · q = back"
+		}
+		ui.KeyHint(hint)
+
+		cmd := strings.TrimSpace(strings.ToLower(ui.Input("Command")))
+
+		switch cmd {
+		case "q", "quit", "back", "":
+			return gitops.CommitSummary{}, false
+		case "n", "next":
+			if page.More {
+				offset += historyPageSize
+			}
+			continue
+		case "p", "prev":
+			offset = max(0, offset-historyPageSize)
+			continue
+		case "f", "filter":
+			editFilter(&filter)
+			offset = 0
+			continue
+		case "a", "all":
+			filter.All = !filter.All
+			offset = 0
+			continue
+		case "h", "help", "?":
+			sectionHelp("History", ui.HelpHistory)
+			continue
+		}
+
+		view, numText := !pick, cmd
+		if rest, ok := strings.CutPrefix(cmd, "v "); ok {
+			view, numText = true, strings.TrimSpace(rest)
+		}
+
+		n, err := strconv.Atoi(numText)
+		if err != nil || n <= offset || n > offset+len(commits) {
+			ui.Error("Invalid command: " + cmd)
+			ui.Pause()
+			continue
+		}
+
+		c := commits[n-offset-1]
+		if !view {
+			return c, true
+		}
+		commitView(c.Hash)
+	}
+}
+
+func showFilter(f gitops.LogFilter) {
+	var parts []string
+	if f.All {
+		parts = append(parts, "all branches")
+	}
+	for _, kv := range [][2]string{
+		{"author", f.Author}, {"path", f.Path}, {"since", f.Since},
+		{"until", f.Until}, {"message", f.Grep},
+	} {
+		if kv[1] != "" {
+			parts = append(parts, kv[0]+": "+kv[1])
+		}
+	}
+	if len(parts) > 0 {
+		fmt.Fprintln(ui.Output(), ui.Yellow+"Filter: "+strings.Join(parts, " · ")+ui.Reset)
+		fmt.Fprintln(ui.Output())
+	}
+}
+
+// showLogPage prints graph rows; commits are numbered across pages
+func showLogPage(page gitops.LogPage, offset int) {
+	if len(page.Lines) == 0 {
+		ui.Warn("No commits match")
+		return
+	}
+
+	n := offset
+	for _, l := range page.Lines {
+		if l.Commit == nil {
+			fmt.Fprintf(ui.Output(), "      %s%s%s\n", ui.Cyan, l.Graph, ui.Reset)
+			continue
+		}
+
+		n++
+		c := l.Commit
+		refs := ""
+		if c.Refs != "" {
+			refs = " " + ui.Green + "(" + c.Refs + ")" + ui.Reset
+		}
+		fmt.Fprintf(ui.Output(), "%4d) %s%s%s%s%s %s%s  %s%s, %s%s\n",
+			n, ui.Cyan, l.Graph, ui.Reset,
+			ui.Yellow, shortHash(c.Hash), c.Subject, refs,
+			ui.Blue, c.Author, ago(c.When), ui.Reset)
+	}
+
+	if page.More {
+		ui.Info("More commits: n = next page")
+	}
+	fmt.Fprintln(ui.Output())
+}
+
+func editFilter(f *gitops.LogFilter) {
+	for {
+		ui.Clear()
+		ui.Header("History Filter")
+		showFilter(*f)
+
+		switch ui.Select("Filter by", []string{
+			"Author",
+			"Path (file or folder)",
+			"Since date (2024-01-31, 2 weeks ago)",
+			"Until date",
+			"Message text",
+			"Clear all filters",
+			"Done",
+		}) {
+		case 1:
+			f.Author = ui.Input("Author name or email (empty = any)")
+		case 2:
+			f.Path = ui.Input("Path (empty = any)")
+		case 3:
+			f.Since = ui.Input("Since (empty = any)")
+		case 4:
+			f.Until = ui.Input("Until (empty = any)")
+		case 5:
+			f.Grep = ui.Input("Message contains (empty = any)")
+		case 6:
+			*f = gitops.LogFilter{All: f.All}
+		default:
+			return
+		}
+	}
+}
+
+// commitView shows message, stats and (on request) the diff viewer
+func commitView(rev string) {
+	info, err := gitops.CommitDetail(rev)
+	if err != nil {
+		renderError("log", err)
+		ui.Pause()
+		return
+	}
+
+	for {
+		ui.Clear()
+		ui.Header("Commit " + shortHash(info.Hash))
+
+		ui.PrintKV("Commit", info.Hash)
+		if len(info.Parents) > 1 {
+			ui.PrintKV("Merge", strings.Join(shortHashes(info.Parents), " + "))
+		}
+		ui.PrintKV("Author", info.Author+" <"+info.Email+">")
+		ui.PrintKV("Date", info.When.Format("2006-01-02 15:04")+"  ("+ago(info.When)+")")
+		if info.Committer != info.Author {
+			ui.PrintKV("Committer", info.Committer)
+		}
+		if info.Refs != "" {
+			ui.PrintKV("Refs", info.Refs)
+		}
+
+		fmt.Fprintln(ui.Output())
+		fmt.Fprintln(ui.Output(), "    "+info.Subject)
+		if info.Body != "" {
+			fmt.Fprintln(ui.Output())
+			for _, l := range strings.Split(info.Body, "\n") {
+				fmt.Fprintln(ui.Output(), "    "+l)
+			}
+		}
+		fmt.Fprintln(ui.Output())
+
+		showFileStats(info.Files)
+
+		ui.KeyHint("d = full diff · Enter = back")
+		switch strings.ToLower(strings.TrimSpace(ui.Input("Command"))) {
+		case "d", "diff":
+			diffViewer("Commit "+shortHash(info.Hash), gitops.CommitChanges(info.CommitSummary))
+		default:
+			return
+		}
+	}
+}
+
+// showFileStats prints a diffstat with +/- bars
+func showFileStats(files []gitops.FileStat) {
+	added, deleted := 0, 0
+	for _, f := range files {
+		added += f.Added
+		deleted += f.Deleted
+	}
+
+	for _, f := range files {
+		if f.Binary {
+			fmt.Fprintf(ui.Output(), "  %-40s %sbinary%s\n", f.Path, ui.Yellow, ui.Reset)
+			continue
+		}
+		fmt.Fprintf(ui.Output(), "  %-40s %5d %s\n", f.Path, f.Added+f.Deleted, statBar(f.Added, f.Deleted))
+	}
+	fmt.Fprintf(ui.Output(), "  %d file(s) changed, %s+%d%s %s-%d%s\n\n",
+		len(files), ui.Green, added, ui.Reset, ui.Red, deleted, ui.Reset)
+}
+
+// statBar scales +/- counts to at most 20 characters
+func statBar(added, deleted int) string {
+	const width = 20
+	total := added + deleted
+	if total > width {
+		added = added * width / total
+		deleted = width - added
+	}
+	return ui.Green + strings.Repeat("+", added) + ui.Red + strings.Repeat("-", deleted) + ui.Reset
+}
+
+func shortHashes(hashes []string) []string {
+	short := make([]string, len(hashes))
+	for i, h := range hashes {
+		short[i] = shortHash(h)
+	}
+	return short
+}
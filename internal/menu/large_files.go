@@ -0,0 +1,94 @@
+package menu
+
+import (
+	"fmt"
+	"strconv"
+
+	"git-genius/internal/config"
+	"git-genius/internal/gitops"
+	"git-genius/internal/ui"
+)
+
+/* ============================================================
+   Large Files (before commit)
+   ============================================================ */
+
+/*
+resolveLargeFiles shows oversized staged files and applies the chosen fix
+The index is then committed as-is (opts.KeepIndex)
+Returns false when the push should stop
+*/
+func resolveLargeFiles(files []gitops.LargeFile, opts *gitops.PushOptions) bool {
+	ui.Divider()
+	ui.Warn(fmt.Sprintf("Files over %d MB (GitHub rejects files over 100 MB)", config.Load().LargeFileMB))
+	paths := make([]string, 0, len(files))
+	for _, f := range files {
+		fmt.Fprintf(ui.Output(), "  %s%10s%s  %s\n", ui.Yellow, gitops.FormatSize(f.Size), ui.Reset, f.Path)
+		paths = append(paths, f.Path)
+	}
+	ui.Divider()
+
+	lfs := "Track with Git LFS"
+	if !gitops.LFSAvailable() {
+		lfs += " (git-lfs not installed)"
+	}
+
+	choice := ui.Select("What should happen to these files?", []string{
+		"Untrack (keep on disk, leave out of this commit)",
+		"Add to .gitignore",
+		lfs,
+		"Commit anyway",
+		"Cancel",
+	})
+
+	var res gitops.Result
+	var err error
+
+	switch choice {
+	case 1:
+		res, err = gitops.UntrackPaths(paths)
+	case 2:
+		res, err = gitops.IgnorePaths(paths)
+	case 3:
+		res, err = gitops.LFSTrack(paths)
+	case 4:
+		opts.AllowLargeFiles = true
+	default:
+		return false
+	}
+
+	if err != nil {
+		Render(res, err)
+		return false
+	}
+	if res.Summary != "" {
+		ui.Success(res.Summary)
+	}
+	for _, w := range res.Warnings {
+		ui.Warn(w)
+	}
+
+	opts.KeepIndex = true
+	return true
+}
+
+// largeFileLimitSetting changes config.LargeFileMB
+func largeFileLimitSetting() {
+	cfg := config.Load()
+	ui.PrintKV("Limit", strconv.Itoa(cfg.LargeFileMB)+" MB")
+
+	input := ui.Input("New limit in MB (Enter = keep)")
+	if input == "" {
+		return
+	}
+
+	n, err := strconv.Atoi(input)
+	if err != nil || n <= 0 {
+		ui.Error("Enter a whole number of MB")
+		return
+	}
+
+	cfg.LargeFileMB = n
+	config.Save(cfg)
+	ui.Success("Large file limit set to " + input + " MB")
+}
@@ -9,6 +9,7 @@ import (
 	"git-genius/internal/doctor"
 	"git-genius/internal/gitops"
 	"git-genius/internal/setup"
+	"git-genius/internal/system"
 	"git-genius/internal/ui"
 )
 
@@ -23,12 +24,19 @@ func Start() {
 		fmt.Println("2) Branch / Remote")
 		fmt.Println("3) Stash & Undo")
 		fmt.Println("4) Tools")
-		fmt.Println("5) Help / About")
-		fmt.Println("6) Exit")
+		fmt.Println("5) History & Blame")
+		fmt.Println("6) Releases (tags, versions)")
+		fmt.Println("7) Help / About")
+		fmt.Println("8) Exit")
+		if p, ok := gitops.PendingOperation(); ok {
+			fmt.Println("p) Finish pending " + p.Kind + " (continue / skip / abort)")
+		}
 		fmt.Println()
 		fmt.Println("Tip: press 'h' for help")
 
 		switch ui.Input("Select option") {
+		case "p", "P":
+			pendingMenu()
 		case "1":
 			dailyMenu()
 		case "2":
@@ -37,9 +45,13 @@ func Start() {
 			stashMenu()
 		case "4":
 			toolsMenu()
-		case "5", "h", "help", "?":
-			mainHelp()
+		case "5":
+			historyMenu()
 		case "6":
+			releasesMenu()
+		case "7", "h", "help", "?":
+			mainHelp()
+		case "8":
 			ui.Info("Goodbye 👋")
 			os.Exit(0)
 		default:
@@ -60,8 +72,23 @@ func showContext() {
 	fmt.Println("Project :", filepath.Base(projectDir))
 	fmt.Println("Path    :", projectDir)
 	fmt.Println("Branch  :", gitops.CurrentBranch())
+	if _, configured, mismatch := gitops.BranchMismatch(); mismatch {
+		fmt.Println(ui.Yellow + "          (config branch: " + configured + ")" + ui.Reset)
+	}
 	fmt.Println("Remote  :", gitops.CurrentRemote())
 
+	if p, ok := gitops.PendingOperation(); ok {
+		showPending(p)
+	}
+
+	if st, err := gitops.ReadStatus(); err == nil {
+		changes := statusSummary(st)
+		if ab := aheadBehind(st); ab != "" {
+			changes += "  " + ab
+		}
+		fmt.Println("Changes :", changes)
+	}
+
 	if cfg.Owner != "" && cfg.Repo != "" {
 		fmt.Println("Repo    :", "https://github.com/"+cfg.Owner+"/"+cfg.Repo)
 	}
@@ -77,27 +104,43 @@ func dailyMenu() {
 		ui.Clear()
 		ui.Header("Daily Git Operations")
 
-		fmt.Println("1) Push changes (commit + push)")
+		fmt.Println("1) Push changes (pick files + commit + push)")
 		fmt.Println("2) Pull changes")
 		fmt.Println("3) Smart Pull (auto-stash + pull)")
 		fmt.Println("4) Fetch all remotes")
 		fmt.Println("5) Git status")
-		fmt.Println("6) Back")
+		fmt.Println("6) Review changes (diff viewer)")
+		fmt.Println("7) Merge a branch into current")
+		fmt.Println("8) Resolve conflicts")
+		fmt.Println("9) Clean up unpushed commits (reorder / squash / reword / drop)")
+		fmt.Println("10) Back")
 		fmt.Println()
 		fmt.Println("Tip: h = help")
 
 		switch ui.Input("Select option") {
 		case "1":
-			gitops.Push(ui.Input("Commit message"))
+			run(InteractivePush)
 		case "2":
-			gitops.Pull()
+			run(gitops.Pull)
 		case "3":
-			gitops.SmartPull()
+			run(SmartPullFlow)
 		case "4":
-			gitops.Fetch()
+			run(gitops.Fetch)
 		case "5":
-			gitops.Status()
+			run(gitops.Status)
 		case "6":
+			if system.EnsureGitRepo() {
+				diffMenu()
+			}
+			continue
+		case "7":
+			run(MergeFlow)
+		case "8":
+			conflictAssistant()
+			continue
+		case "9":
+			run(RewriteFlow)
+		case "10":
 			return
 		case "h", "help", "?":
 			sectionHelp("Daily Git Operations", ui.HelpDaily)
@@ -118,17 +161,23 @@ func branchMenu() {
 		ui.Header("Branch / Remote")
 
 		fmt.Println("1) Switch branch")
-		fmt.Println("2) Switch remote")
-		fmt.Println("3) Back")
+		fmt.Println("2) Branch manager (list, rename, delete, upstream, cleanup)")
+		fmt.Println("3) Switch remote")
+		fmt.Println("4) Back")
 		fmt.Println()
 		fmt.Println("Tip: h = help")
 
 		switch ui.Input("Select option") {
 		case "1":
-			gitops.SwitchBranch()
+			name := ui.Input("Branch name (existing, origin/<name> or new)")
+			run(func() (gitops.Result, error) { return SwitchFlow(name, gitops.DirtyRefuse) })
 		case "2":
-			gitops.SwitchRemote()
+			branchManager()
+			continue
 		case "3":
+			name, url := ui.Input("Remote name"), ui.Input("Remote URL")
+			run(func() (gitops.Result, error) { return gitops.SwitchRemote(name, url) })
+		case "4":
 			return
 		case "h", "help", "?":
 			sectionHelp("Branch / Remote", ui.HelpBranch)
@@ -149,23 +198,31 @@ func stashMenu() {
 		ui.Header("Stash & Undo")
 
 		fmt.Println("1) Stash changes")
-		fmt.Println("2) List stashes")
+		fmt.Println("2) Stash manager (diff, apply, pop, rename, branch, drop)")
 		fmt.Println("3) Apply last stash (pop)")
 		fmt.Println("4) Undo last commit (keep changes)")
-		fmt.Println("5) Back")
+		fmt.Println("5) Undo last git-genius operation")
+		fmt.Println("6) Recovery center (reflog)")
+		fmt.Println("7) Back")
 		fmt.Println()
 		fmt.Println("Tip: h = help")
 
 		switch ui.Input("Select option") {
 		case "1":
-			gitops.StashSave()
+			run(StashSaveFlow)
 		case "2":
-			gitops.StashList()
+			stashManager()
+			continue
 		case "3":
-			gitops.StashPop()
+			run(gitops.StashPop)
 		case "4":
-			gitops.UndoLastCommit()
+			run(UndoFlow)
 		case "5":
+			run(UndoOperationFlow)
+		case "6":
+			recoveryMenu()
+			continue
+		case "7":
 			return
 		case "h", "help", "?":
 			sectionHelp("Stash & Undo", ui.HelpStash)
@@ -189,7 +246,9 @@ func toolsMenu() {
 		fmt.Println("2) Create / Link GitHub Repository")
 		fmt.Println("3) Change Project Directory")
 		fmt.Println("4) Doctor (health check)")
-		fmt.Println("5) Back")
+		fmt.Println("5) Commit message rules")
+		fmt.Println("6) Large file limit")
+		fmt.Println("7) Back")
 		fmt.Println()
 		fmt.Println("Tip: h = help")
 
@@ -203,6 +262,11 @@ func toolsMenu() {
 		case "4":
 			doctor.Run()
 		case "5":
+			commitRulesMenu()
+			continue
+		case "6":
+			largeFileLimitSetting()
+		case "7":
 			return
 		case "h", "help", "?":
 			sectionHelp("Tools", ui.HelpTools)
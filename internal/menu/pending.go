@@ -0,0 +1,119 @@
+package menu
+
+import (
+	"fmt"
+	"strings"
+
+	"git-genius/internal/gitops"
+	"git-genius/internal/ui"
+)
+
+/* ============================================================
+   Pending Operation (merge / rebase / cherry-pick / revert / bisect)
+   ============================================================ */
+
+// showPending prints a banner in the context panel
+func showPending(p gitops.Pending) {
+	line := "⚠ " + strings.ToUpper(p.Kind) + " IN PROGRESS"
+	if p.Detail != "" {
+		line += "  (" + p.Detail + ")"
+	}
+	fmt.Println(ui.Red + line + ui.Reset)
+
+	if n := len(gitops.ConflictedPaths()); n > 0 {
+		fmt.Println(ui.Yellow + fmt.Sprintf("  %d conflicted file(s)", n) + ui.Reset)
+	}
+	fmt.Println(ui.Yellow + "  p = continue / skip / abort" + ui.Reset)
+}
+
+func pendingMenu() {
+	for {
+		p, ok := gitops.PendingOperation()
+		if !ok {
+			ui.Success("No operation in progress")
+			ui.Pause()
+			return
+		}
+
+		ui.Clear()
+		ui.Header(strings.ToUpper(p.Kind[:1]) + p.Kind[1:] + " in progress")
+		if p.Detail != "" {
+			ui.PrintKV("Status", p.Detail)
+		}
+		conflicts := len(gitops.ConflictedPaths())
+		ui.PrintKV("Conflicts", fmt.Sprint(conflicts))
+		fmt.Println()
+
+		if p.Kind == gitops.PendingBisect {
+			if !bisectMenu() {
+				return
+			}
+			ui.Pause()
+			continue
+		}
+
+		fmt.Println("1) Resolve conflicts")
+		fmt.Println("2) Continue " + p.Kind)
+		if p.CanSkip() {
+			fmt.Println("3) Skip this commit")
+		}
+		fmt.Println("4) Abort " + p.Kind + " (back to the state before)")
+		fmt.Println("5) Back")
+		fmt.Println()
+		fmt.Println("Tip: h = help")
+
+		switch ui.Input("Select option") {
+		case "1":
+			conflictAssistant()
+			continue
+		case "2":
+			run(gitops.ContinuePending)
+		case "3":
+			if !p.CanSkip() {
+				ui.Error("Invalid option")
+				break
+			}
+			if ui.Confirm("Skip the current commit? (its changes are dropped)") {
+				run(gitops.SkipPending)
+			}
+		case "4":
+			if ui.Confirm("Abort the " + p.Kind + "?") {
+				run(gitops.AbortPending)
+			}
+		case "5":
+			return
+		case "h", "help", "?":
+			sectionHelp("Pending Operation", ui.HelpPending)
+			continue
+		default:
+			ui.Error("Invalid option")
+		}
+		ui.Pause()
+	}
+}
+
+// bisectMenu returns false when the user goes back
+func bisectMenu() bool {
+	fmt.Println("1) Mark current commit good")
+	fmt.Println("2) Mark current commit bad")
+	fmt.Println("3) Skip (cannot test this commit)")
+	fmt.Println("4) Finish / abort bisect")
+	fmt.Println("5) Back")
+	fmt.Println()
+
+	switch ui.Input("Select option") {
+	case "1":
+		run(func() (gitops.Result, error) { return gitops.BisectMark(true) })
+	case "2":
+		run(func() (gitops.Result, error) { return gitops.BisectMark(false) })
+	case "3":
+		run(gitops.SkipPending)
+	case "4":
+		run(gitops.AbortPending)
+	case "5":
+		return false
+	default:
+		ui.Error("Invalid option")
+	}
+	return true
+}
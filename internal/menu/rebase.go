@@ -0,0 +1,219 @@
+package menu
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"git-genius/internal/gitops"
+	"git-genius/internal/ui"
+)
+
+/* ============================================================
+   Clean up unpushed commits (rebase -i without the todo file)
+   ============================================================ */
+
+// actionKeys maps the one-letter commands to rebase actions
+var actionKeys = map[string]string{
+	"p": gitops.ActionPick,
+	"r": gitops.ActionReword,
+	"s": gitops.ActionSquash,
+	"f": gitops.ActionFixup,
+	"d": gitops.ActionDrop,
+}
+
+/*
+RewriteFlow lists unpushed commits (oldest first) and lets the user
+reorder, squash, fixup, reword or drop them before RewriteCommits runs
+
+Commands:
+
+	2 s / 3-4 f    set action (p pick, r reword, s squash, f fixup, d drop)
+	m 3 1          move commit 3 to position 1
+	Enter          preview + apply
+	q              cancel
+*/
+func RewriteFlow() (gitops.Result, error) {
+	op := gitops.Result{Operation: "rewrite-commits"}
+
+	items, err := gitops.UnpushedCommits()
+	if err != nil {
+		return op, err
+	}
+	if len(items) == 0 {
+		op.Summary = "No unpushed commits to clean up"
+		return op, nil
+	}
+
+	original := make([]string, len(items))
+	for i, it := range items {
+		original[i] = it.Hash
+	}
+
+	for {
+		ui.Clear()
+		ui.Header("Clean Up Unpushed Commits")
+		showTodo(items)
+		ui.KeyHint("<n> p/r/s/f/d = pick/reword/squash/fixup/drop · m <from> <to> = move · Enter = apply · q = cancel")
+
+		cmd := strings.TrimSpace(ui.Input("Command"))
+
+		switch strings.ToLower(cmd) {
+		case "", "done":
+			if !previewTodo(items, original) {
+				continue
+			}
+			ui.Info("Rewriting commits...")
+			return gitops.RewriteCommits(items)
+		case "q", "quit", "cancel":
+			return op, gitops.ErrCancelled
+		}
+
+		if err := applyTodoCommand(items, cmd); err != nil {
+			ui.Error(err.Error())
+			ui.Pause()
+		}
+	}
+}
+
+func showTodo(items []gitops.TodoItem) {
+	for i, it := range items {
+		color := ""
+		switch it.Action {
+		case gitops.ActionDrop:
+			color = ui.Red
+		case gitops.ActionSquash, gitops.ActionFixup:
+			color = ui.Yellow
+		case gitops.ActionReword:
+			color = ui.Cyan
+		}
+		fmt.Fprintf(ui.Output(), " %2d) %s%-6s%s %s %s  %s(%s, %s)%s\n",
+			i+1, color, it.Action, ui.Reset, shortHash(it.Hash), it.Subject,
+			ui.Blue, it.Author, ago(it.When), ui.Reset)
+
+		if it.Action == gitops.ActionReword {
+			fmt.Fprintf(ui.Output(), "             → %s\n", firstLine(it.Message))
+		}
+	}
+	fmt.Println()
+}
+
+// applyTodoCommand handles "<numbers> <action>" and "m <from> <to>"
+func applyTodoCommand(items []gitops.TodoItem, cmd string) error {
+	fields := strings.Fields(strings.ToLower(cmd))
+
+	if len(fields) == 3 && fields[0] == "m" {
+		from, err1 := strconv.Atoi(fields[1])
+		to, err2 := strconv.Atoi(fields[2])
+		if err1 != nil || err2 != nil || from < 1 || to < 1 || from > len(items) || to > len(items) {
+			return fmt.Errorf("usage: m <from> <to> (1-%d)", len(items))
+		}
+		moveTodo(items, from-1, to-1)
+		return nil
+	}
+
+	if len(fields) != 2 {
+		return fmt.Errorf("invalid command: %s", cmd)
+	}
+
+	action, ok := actionKeys[fields[1]]
+	if !ok {
+		return fmt.Errorf("unknown action %q (p, r, s, f, d)", fields[1])
+	}
+
+	picks, ok := parseNumbers(fields[0], len(items))
+	if !ok {
+		return fmt.Errorf("invalid commit number: %s", fields[0])
+	}
+
+	for _, i := range picks {
+		if action == gitops.ActionReword {
+			ui.Info("New message for " + shortHash(items[i].Hash) + " (was: " + items[i].Subject + ")")
+			msg, ok := askCommitMessage(false, nil)
+			if !ok {
+				return fmt.Errorf("reword cancelled")
+			}
+			items[i].Message = msg
+		}
+		items[i].Action = action
+	}
+	return nil
+}
+
+// parseNumbers parses "3", "2-4" or "1,3" into 0-based indexes
+func parseNumbers(s string, n int) ([]int, bool) {
+	var picks []int
+
+	for _, tok := range strings.Split(s, ",") {
+		lo, hi, isRange := strings.Cut(tok, "-")
+		a, err := strconv.Atoi(lo)
+		if err != nil {
+			return nil, false
+		}
+		b := a
+		if isRange {
+			if b, err = strconv.Atoi(hi); err != nil {
+				return nil, false
+			}
+		}
+		if a < 1 || b > n || a > b {
+			return nil, false
+		}
+		for k := a; k <= b; k++ {
+			picks = append(picks, k-1)
+		}
+	}
+	return picks, len(picks) > 0
+}
+
+func moveTodo(items []gitops.TodoItem, from, to int) {
+	it := items[from]
+	if from < to {
+		copy(items[from:to], items[from+1:to+1])
+	} else {
+		copy(items[to+1:from+1], items[to:from])
+	}
+	items[to] = it
+}
+
+/*
+previewTodo shows the history that will result and asks to apply it
+Squash / fixup lines are shown under the commit they join
+*/
+func previewTodo(items []gitops.TodoItem, original []string) bool {
+	ui.Divider()
+	ui.Info("Resulting history (oldest first)")
+
+	changed := false
+	for i, it := range items {
+		if it.Action != gitops.ActionPick || it.Hash != original[i] {
+			changed = true
+		}
+
+		switch it.Action {
+		case gitops.ActionDrop:
+			fmt.Fprintf(ui.Output(), "  %s✗ %s %s%s\n", ui.Red, shortHash(it.Hash), it.Subject, ui.Reset)
+		case gitops.ActionSquash, gitops.ActionFixup:
+			fmt.Fprintf(ui.Output(), "     + %s %s (%s)\n", shortHash(it.Hash), it.Subject, it.Action)
+		case gitops.ActionReword:
+			fmt.Fprintf(ui.Output(), "  • %s\n", firstLine(it.Message))
+		default:
+			fmt.Fprintf(ui.Output(), "  • %s\n", it.Subject)
+		}
+	}
+	ui.Divider()
+
+	if !changed {
+		ui.Warn("Nothing changed yet")
+		ui.Pause()
+		return false
+	}
+
+	ui.Info("A backup ref is created first; on any failure the rebase is aborted")
+	return ui.Confirm("Rewrite these commits?")
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
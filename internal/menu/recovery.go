@@ -0,0 +1,215 @@
+package menu
+
+import (
+	"fmt"
+	"time"
+
+	"git-genius/internal/gitops"
+	"git-genius/internal/ui"
+)
+
+/* ============================================================
+   Recovery Center (reflog based)
+   ============================================================ */
+
+// timelineSize = reflog entries shown in the timeline
+const timelineSize = 30
+
+func recoveryMenu() {
+	for {
+		ui.Clear()
+		ui.Header("Recovery Center")
+
+		fmt.Println("1) Timeline (restore HEAD to an earlier point)")
+		fmt.Println("2) Restore HEAD to a commit from history")
+		fmt.Println("3) Recreate a deleted branch")
+		fmt.Println("4) Recover a dropped stash")
+		fmt.Println("5) Backups made by Git Genius")
+		fmt.Println("6) Back")
+		fmt.Println()
+		fmt.Println("Tip: h = help")
+
+		switch ui.Input("Select option") {
+		case "1":
+			run(timelineFlow)
+		case "2":
+			run(historyRestoreFlow)
+		case "3":
+			run(deletedBranchFlow)
+		case "4":
+			run(droppedStashFlow)
+		case "5":
+			run(backupFlow)
+		case "6":
+			return
+		case "h", "help", "?":
+			sectionHelp("Recovery Center", ui.HelpRecovery)
+		default:
+			ui.Error("Invalid option")
+		}
+		ui.Pause()
+	}
+}
+
+// timelineFlow shows the reflog and restores HEAD to the chosen entry
+func timelineFlow() (gitops.Result, error) {
+	res, err := gitops.Reflog(timelineSize)
+	if err != nil {
+		return res, err
+	}
+	entries, _ := res.Data.([]gitops.ReflogEntry)
+
+	options := make([]string, 0, len(entries)+1)
+	for _, e := range entries {
+		options = append(options, fmt.Sprintf("%-9s %s%s%s  %-10s %s",
+			ago(e.When), ui.Yellow, shortHash(e.Hash), ui.Reset, e.Action, e.Message))
+	}
+	options = append(options, "Back")
+
+	choice := ui.Select("HEAD timeline (newest first)", options)
+	if choice < 1 || choice > len(entries) {
+		return gitops.Result{Operation: "restore-head"}, gitops.ErrCancelled
+	}
+
+	e := entries[choice-1]
+	return confirmRestore(e.Hash, e.Selector+" ("+e.Action+": "+e.Message+")")
+}
+
+// historyRestoreFlow picks the target commit in the history browser
+func historyRestoreFlow() (gitops.Result, error) {
+	c, ok := PickCommit("Restore HEAD to…")
+	if !ok {
+		return gitops.Result{Operation: "restore-head"}, gitops.ErrCancelled
+	}
+	return confirmRestore(c.Hash, c.Subject+" ("+ago(c.When)+")")
+}
+
+// deletedBranchFlow recreates a branch found in the reflog
+func deletedBranchFlow() (gitops.Result, error) {
+	op := gitops.Result{Operation: "recreate-branch"}
+
+	branches, err := gitops.DeletedBranches()
+	if err != nil {
+		return op, err
+	}
+	if len(branches) == 0 {
+		op.Summary = "No deleted branches found in the reflog"
+		return op, nil
+	}
+
+	options := make([]string, 0, len(branches)+1)
+	for _, b := range branches {
+		options = append(options, fmt.Sprintf("%-24s %s%s%s  left %s",
+			b.Name, ui.Yellow, shortHash(b.Hash), ui.Reset, ago(b.When)))
+	}
+	options = append(options, "Back")
+
+	choice := ui.Select("Deleted branches", options)
+	if choice < 1 || choice > len(branches) {
+		return op, gitops.ErrCancelled
+	}
+
+	b := branches[choice-1]
+	name := ui.Input("Branch name (Enter = " + b.Name + ")")
+	if name == "" {
+		name = b.Name
+	}
+	return gitops.RecreateBranch(name, b.Hash)
+}
+
+// droppedStashFlow puts an unreachable stash back on the stash list
+func droppedStashFlow() (gitops.Result, error) {
+	op := gitops.Result{Operation: "recover-stash"}
+
+	ui.Info("Searching for dropped stashes (git fsck)...")
+	stashes, err := gitops.DroppedStashes()
+	if err != nil {
+		return op, err
+	}
+	if len(stashes) == 0 {
+		op.Summary = "No dropped stashes found"
+		return op, nil
+	}
+
+	options := make([]string, 0, len(stashes)+1)
+	for _, s := range stashes {
+		options = append(options, fmt.Sprintf("%-9s %s%s%s  %s",
+			ago(s.When), ui.Yellow, shortHash(s.Hash), ui.Reset, s.Message))
+	}
+	options = append(options, "Back")
+
+	choice := ui.Select("Dropped stashes", options)
+	if choice < 1 || choice > len(stashes) {
+		return op, gitops.ErrCancelled
+	}
+	return gitops.RecoverStash(stashes[choice-1])
+}
+
+// backupFlow restores HEAD to a backup ref
+func backupFlow() (gitops.Result, error) {
+	op := gitops.Result{Operation: "restore-head"}
+
+	backups, err := gitops.Backups()
+	if err != nil {
+		return op, err
+	}
+	if len(backups) == 0 {
+		op.Summary = "No backups yet (created before every recovery)"
+		return op, nil
+	}
+
+	options := make([]string, 0, len(backups)+1)
+	for _, b := range backups {
+		before := ""
+		if b.Operation != "" {
+			before = "before " + b.Operation + "  "
+		}
+		options = append(options, fmt.Sprintf("%-9s %s%s%s  %s%s",
+			ago(b.When), ui.Yellow, shortHash(b.Hash), ui.Reset, before, b.Ref))
+	}
+	options = append(options, "Back")
+
+	choice := ui.Select("Backups", options)
+	if choice < 1 || choice > len(backups) {
+		return op, gitops.ErrCancelled
+	}
+
+	b := backups[choice-1]
+	return confirmRestore(b.Hash, b.Ref)
+}
+
+func confirmRestore(hash, label string) (gitops.Result, error) {
+	branch := gitops.CurrentBranch()
+	if branch == "-" {
+		branch = "HEAD"
+	}
+
+	ui.Info("Target : " + label)
+	ui.Info("A backup ref of the current position is created first")
+	if !ui.Confirm("Move " + branch + " to " + shortHash(hash) + "?") {
+		return gitops.Result{Operation: "restore-head"}, gitops.ErrCancelled
+	}
+	return gitops.RestoreHead(hash)
+}
+
+// ago renders t relative to now ("5m ago", "3d ago")
+func ago(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	case d < 30*24*time.Hour:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	case d < 365*24*time.Hour:
+		return fmt.Sprintf("%dmo ago", int(d.Hours()/24/30))
+	}
+	return fmt.Sprintf("%dy ago", int(d.Hours()/24/365))
+}
@@ -0,0 +1,330 @@
+package menu
+
+import (
+	"fmt"
+	"strings"
+
+	"git-genius/internal/config"
+	"git-genius/internal/gitops"
+	"git-genius/internal/semver"
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+/* ============================================================
+   Releases (tags + semantic versions)
+   ============================================================ */
+
+// releaseCommitPreview = commits listed in the release summary
+const releaseCommitPreview = 10
+
+func releasesMenu() {
+	if !system.EnsureGitRepo() {
+		return
+	}
+
+	for {
+		ui.Clear()
+		ui.Header("Releases")
+
+		fmt.Println("1) List tags")
+		fmt.Println("2) Create release tag (next version)")
+		fmt.Println("3) Publish tags to " + config.Load().Remote)
+		fmt.Println("4) Changelog (Markdown from commits)")
+		fmt.Println("5) Back")
+		fmt.Println()
+		fmt.Println("Tip: h = help")
+
+		switch ui.Input("Select option") {
+		case "1":
+			run(gitops.Tags)
+		case "2":
+			run(ReleaseFlow)
+		case "3":
+			run(PublishTagsFlow)
+		case "4":
+			run(ChangelogFlow)
+		case "5":
+			return
+		case "h", "help", "?":
+			sectionHelp("Releases", ui.HelpReleases)
+			continue
+		default:
+			ui.Error("Invalid option")
+		}
+		ui.Pause()
+	}
+}
+
+func showTags(tags []gitops.TagInfo) {
+	if len(tags) == 0 {
+		ui.Info("No tags yet")
+		return
+	}
+
+	for _, t := range tags {
+		kind := "lightweight"
+		if t.Annotated {
+			kind = "annotated"
+		}
+		fmt.Fprintf(ui.Output(), " %-20s %s%s%s %-11s %s  %s(%s)%s\n",
+			t.Name, ui.Yellow, shortHash(t.Commit), ui.Reset, kind, t.Subject,
+			ui.Blue, ago(t.When), ui.Reset)
+	}
+}
+
+/*
+ReleaseFlow suggests the next version from the latest tag and the
+Conventional Commit types since then, creates the tag and offers to publish it
+*/
+func ReleaseFlow() (gitops.Result, error) {
+	res := gitops.Result{Operation: "create-tag"}
+
+	plan, err := gitops.PlanRelease()
+	if err != nil {
+		return res, err
+	}
+	showPlan(plan)
+
+	if plan.CurrentTag != "" && !plan.Unreleased {
+		ui.Warn("HEAD is already released as " + plan.CurrentTag)
+		if !ui.ConfirmDefault("Tag it again with a new version?", false) {
+			return res, gitops.ErrCancelled
+		}
+	}
+
+	next, ok := askVersion(plan)
+	if !ok {
+		return res, gitops.ErrCancelled
+	}
+	name := next.String()
+
+	def := "Release " + name
+	message := strings.TrimSpace(ui.Input("Tag message (Enter = " + def + ")"))
+	if message == "" {
+		message = def
+	}
+	sign := ui.ConfirmDefault("Sign the tag (gpg / ssh key)?", gitops.TagSigningDefault())
+
+	kind := "annotated"
+	if sign {
+		kind = "signed"
+	}
+	fmt.Fprintln(ui.Output())
+	ui.Divider()
+	ui.PrintKV("Tag", name+" ("+kind+")")
+	ui.PrintKV("Commit", "HEAD "+gitops.CurrentBranch())
+	ui.PrintKV("Message", message)
+	ui.Divider()
+
+	if !ui.Confirm("Create tag " + name + "?") {
+		return res, gitops.ErrCancelled
+	}
+
+	res, err = gitops.CreateTag(name, message, "", sign)
+	if err != nil {
+		return res, err
+	}
+
+	if gitops.CurrentRemote() == "-" || !ui.ConfirmDefault("Publish "+name+" to "+config.Load().Remote+" now?", false) {
+		return res, nil
+	}
+	ui.Success(res.Summary)
+
+	tags, err := gitops.ListTags()
+	if err != nil {
+		return res, err
+	}
+	for _, t := range tags {
+		if t.Name == name {
+			return publishTags([]gitops.TagInfo{t})
+		}
+	}
+	return res, nil
+}
+
+// showPlan prints the latest version and what changed since
+func showPlan(plan gitops.ReleasePlan) {
+	current := plan.CurrentTag
+	if current == "" {
+		current = "none (first release)"
+	}
+	ui.PrintKV("Current version", current)
+	ui.PrintKV("Commits since", fmt.Sprintf("%d (breaking %d, features %d, fixes %d)",
+		len(plan.Commits), plan.Breaking, plan.Features, plan.Fixes))
+
+	for i, c := range plan.Commits {
+		if i == releaseCommitPreview {
+			fmt.Fprintf(ui.Output(), "   … %d more\n", len(plan.Commits)-i)
+			break
+		}
+		mark := " "
+		if c.Message.Breaking {
+			mark = ui.Red + "!" + ui.Reset
+		}
+		fmt.Fprintf(ui.Output(), " %s %s%s%s %s\n", mark, ui.Yellow, shortHash(c.Hash), ui.Reset, c.Subject)
+	}
+	fmt.Fprintln(ui.Output())
+}
+
+// askVersion offers patch / minor / major, a pre-release or a custom version
+func askVersion(plan gitops.ReleasePlan) (semver.Version, bool) {
+	kinds := []string{semver.Patch, semver.Minor, semver.Major}
+	if plan.CurrentTag == "" {
+		kinds = []string{semver.Minor, semver.Major}
+	}
+
+	var versions []semver.Version
+	var options []string
+	for _, k := range kinds {
+		v := plan.Current.Bump(k)
+		if k == semver.Minor && plan.CurrentTag == "" {
+			v = plan.Next
+		}
+		// finishing a pre-release: patch and minor can give the same version
+		if len(versions) > 0 && semver.Compare(v, versions[len(versions)-1]) == 0 {
+			continue
+		}
+		label := fmt.Sprintf("%-6s → %s", k, v)
+		if semver.Compare(v, plan.Next) == 0 {
+			label += "  (suggested)"
+		}
+		versions = append(versions, v)
+		options = append(options, label)
+	}
+	options = append(options, "pre-release of "+plan.Next.Core().String(), "custom version")
+
+	choice := ui.Select("Next version", options)
+	switch {
+	case choice < 1:
+		return semver.Version{}, false
+	case choice <= len(versions):
+		return versions[choice-1], true
+	case choice == len(versions)+1:
+		label := strings.TrimSpace(ui.Input("Pre-release label (Enter = rc)"))
+		if label == "" {
+			label = "rc"
+		}
+		return semver.PreRelease(plan.Current, plan.Next.Core(), label), true
+	}
+
+	text := strings.TrimSpace(ui.Input("Version (e.g. " + plan.Next.String() + ")"))
+	v, ok := semver.Parse(text)
+	if !ok {
+		ui.Error("Not a semantic version: " + text)
+		return v, false
+	}
+	if plan.CurrentTag != "" && semver.Compare(v, plan.Current) <= 0 {
+		ui.Error(text + " is not newer than " + plan.CurrentTag)
+		return v, false
+	}
+	return v, true
+}
+
+/*
+ChangelogFlow builds the Markdown changelog between two tags (or the
+last tag and HEAD) and optionally prepends it to CHANGELOG.md
+*/
+func ChangelogFlow() (gitops.Result, error) {
+	res := gitops.Result{Operation: "changelog"}
+
+	to := strings.TrimSpace(ui.Input("Up to (tag or commit, Enter = HEAD)"))
+	if to == "" {
+		to = "HEAD"
+	}
+
+	prev := gitops.PreviousVersionTag(to)
+	hint := "Enter = first commit"
+	if prev != "" {
+		hint = "Enter = " + prev
+	}
+	from := strings.TrimSpace(ui.Input("Since (tag or commit, " + hint + ")"))
+	if from == "" {
+		from = prev
+	}
+
+	title := ""
+	if to == "HEAD" {
+		title = strings.TrimSpace(ui.Input("Heading (next version, Enter = Unreleased)"))
+	}
+
+	cl, err := gitops.BuildChangelog(from, to, title)
+	if err != nil {
+		return res, err
+	}
+
+	fmt.Fprintln(ui.Output())
+	ui.Divider()
+	fmt.Fprint(ui.Output(), cl.Markdown)
+	ui.Divider()
+	if config.Load().Owner == "" {
+		ui.Info("Link #numbers and commits: set the GitHub owner / repo in Tools → Setup")
+	}
+
+	if !ui.ConfirmDefault("Add it to the top of "+gitops.ChangelogFile+"?", false) {
+		res.Summary = fmt.Sprintf("Changelog %s: %d commit(s)", cl.Title, cl.Commits)
+		return res, nil
+	}
+	commit := ui.ConfirmDefault("Commit "+gitops.ChangelogFile+"?", true)
+	return gitops.PrependChangelog(cl, commit)
+}
+
+// PublishTagsFlow pushes the tags the remote does not have yet
+func PublishTagsFlow() (gitops.Result, error) {
+	res := gitops.Result{Operation: "push-tags"}
+
+	ui.Info("Checking tags on " + config.Load().Remote + "...")
+	tags, err := gitops.UnpublishedTags()
+	if err != nil {
+		return res, err
+	}
+	if len(tags) == 0 {
+		res.Summary = "All tags are already on " + config.Load().Remote
+		return res, nil
+	}
+
+	items := make([]ui.PickItem, 0, len(tags))
+	for _, t := range tags {
+		items = append(items, ui.PickItem{
+			Label:    fmt.Sprintf("%-20s %s %s", t.Name, shortHash(t.Commit), t.CommitSubject),
+			Value:    t.Name,
+			Selected: t.Version != "",
+		})
+	}
+
+	idx, ok := ui.MultiSelect("Not on "+config.Load().Remote+" yet – publish which?", items)
+	if !ok || len(idx) == 0 {
+		return res, gitops.ErrCancelled
+	}
+
+	selected := make([]gitops.TagInfo, 0, len(idx))
+	for _, i := range idx {
+		selected = append(selected, tags[i])
+	}
+	return publishTags(selected)
+}
+
+// publishTags previews what the remote will receive, then pushes
+func publishTags(tags []gitops.TagInfo) (gitops.Result, error) {
+	res := gitops.Result{Operation: "push-tags"}
+	remote := config.Load().Remote
+
+	fmt.Fprintln(ui.Output())
+	ui.Divider()
+	ui.PrintKV("Remote", remote+" "+gitops.RemoteURL())
+	names := make([]string, 0, len(tags))
+	for _, t := range tags {
+		fmt.Fprintf(ui.Output(), " %s%-20s%s → %s%s%s %s\n",
+			ui.Green, t.Name, ui.Reset, ui.Yellow, shortHash(t.Commit), ui.Reset, t.CommitSubject)
+		if n := gitops.CommitsNotOnRemote(t.Commit); n > 0 {
+			ui.Warn(fmt.Sprintf("   also publishes %d commit(s) that are on no %s branch yet", n, remote))
+		}
+		names = append(names, t.Name)
+	}
+	ui.Divider()
+
+	if !ui.Confirm(fmt.Sprintf("Publish %d tag(s) to %s?", len(names), remote)) {
+		return res, gitops.ErrCancelled
+	}
+	return gitops.PushTags(names)
+}
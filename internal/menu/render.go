@@ -0,0 +1,231 @@
+package menu
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"git-genius/internal/config"
+	"git-genius/internal/diffview"
+	"git-genius/internal/gitops"
+	"git-genius/internal/secrets"
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+/* ============================================================
+   Result Rendering (gitops never prints)
+   ============================================================ */
+
+// operation titles used in generic failure messages
+var opTitles = map[string]string{
+	"status":               "Git status",
+	"push":                 "Push",
+	"pull":                 "Pull",
+	"smart-pull":           "Smart pull",
+	"fetch":                "Fetch",
+	"switch":               "Branch switch",
+	"switch-remote":        "Remote update",
+	"stash-save":           "Stash",
+	"stash-list":           "Stash list",
+	"stash-pop":            "Stash pop",
+	"stash-apply":          "Stash apply",
+	"stash-drop":           "Stash drop",
+	"stash-rename":         "Stash rename",
+	"stash-branch":         "Branch from stash",
+	"undo":                 "Undo last commit",
+	"branches":             "Branch list",
+	"rename-branch":        "Rename",
+	"delete-branch":        "Delete branch",
+	"set-upstream":         "Set upstream",
+	"publish-branch":       "Publish branch",
+	"cleanup-branches":     "Branch cleanup",
+	"merge":                "Merge",
+	"continue":             "Continue",
+	"skip":                 "Skip",
+	"abort":                "Abort",
+	"merge-continue":       "Merge",
+	"rebase-continue":      "Rebase",
+	"rebase-skip":          "Rebase skip",
+	"rebase-abort":         "Rebase abort",
+	"cherry-pick-continue": "Cherry-pick",
+	"revert-continue":      "Revert",
+	"bisect-mark":          "Bisect",
+	"merge-complete":       "Merge",
+	"merge-abort":          "Merge abort",
+	"resolve":              "Conflict resolution",
+	"rewrite-commits":      "Commit cleanup",
+	"reflog":               "Reflog",
+	"log":                  "History",
+	"diff":                 "Diff",
+	"blame":                "Blame",
+	"file-history":         "File history",
+	"tags":                 "Tag list",
+	"create-tag":           "Tag",
+	"push-tags":            "Tag publish",
+	"changelog":            "Changelog",
+	"update-changelog":     "Changelog update",
+	"undo-operation":       "Undo operation",
+	"restore-head":         "Restore",
+	"recreate-branch":      "Branch recovery",
+	"recover-stash":        "Stash recovery",
+	"untrack":              "Untrack",
+	"ignore":               "Add to .gitignore",
+	"lfs-track":            "Git LFS track",
+}
+
+/*
+Render prints the outcome of a gitops operation
+Shared by the menu and the CLI (human mode)
+*/
+func Render(res gitops.Result, err error) {
+	if res.Output != "" {
+		fmt.Fprintln(ui.Output(), res.Output)
+	}
+
+	switch data := res.Data.(type) {
+	case gitops.RepoStatus:
+		ShowStatus(data)
+	case gitops.LogPage:
+		showLogPage(data, 0)
+	case []gitops.StashEntry:
+		showStashes(data)
+	case gitops.Changelog:
+		fmt.Fprint(ui.Output(), data.Markdown)
+	case []gitops.TagInfo:
+		showTags(data)
+	case []gitops.FileDiff:
+		for _, f := range data {
+			diffview.Render(ui.Output(), f, diffOpts)
+			fmt.Fprintln(ui.Output())
+		}
+	}
+
+	for _, w := range res.Warnings {
+		ui.Warn(w)
+	}
+
+	if err != nil {
+		renderError(res.Operation, err)
+		return
+	}
+
+	if res.Summary != "" {
+		ui.Success(res.Summary)
+	}
+}
+
+func renderError(op string, err error) {
+	switch {
+	case errors.Is(err, gitops.ErrNotRepo):
+		ui.Error("Git repository required to continue")
+	case errors.Is(err, gitops.ErrNothingToCommit) && op == "stash-save":
+		ui.Warn("Nothing to stash")
+		ui.Info("Only new files? Include untracked files (git-genius stash save -u)")
+	case errors.Is(err, gitops.ErrNothingToCommit):
+		ui.Warn("Nothing to commit")
+	case errors.Is(err, gitops.ErrEmptyMessage):
+		ui.Error("Commit message cannot be empty")
+	case errors.Is(err, gitops.ErrNoRemote):
+		ui.Warn("No remote configured")
+		ui.Info("Run: Tools → Create / Link GitHub Repository")
+	case errors.Is(err, gitops.ErrNoCommits):
+		ui.Warn("No commits found")
+	case errors.Is(err, gitops.ErrCancelled):
+		ui.Warn(title(op) + " cancelled")
+	case errors.Is(err, gitops.ErrInvalidArguments):
+		ui.Error("Required value missing or invalid")
+	case errors.Is(err, gitops.ErrOperationPending):
+		p, _ := gitops.PendingOperation()
+		ui.Error(title(op) + " blocked: " + p.Kind + " in progress")
+		ui.Info("Finish it first: main menu → p (continue / skip / abort)")
+	case errors.Is(err, gitops.ErrRewriteAborted):
+		ui.Error("Commit cleanup aborted: " + strings.TrimPrefix(err.Error(), gitops.ErrRewriteAborted.Error()+": "))
+		ui.Info("Your branch is unchanged; try a different order or fewer changes")
+	case errors.Is(err, gitops.ErrNothingPending):
+		ui.Warn("No operation in progress")
+	case errors.Is(err, gitops.ErrBinaryConflict):
+		ui.Warn("Binary file: pick one side as a whole")
+	case errors.Is(err, gitops.ErrNotMerged):
+		ui.Warn("Branch is not merged into " + config.Load().DefaultBranch)
+		ui.Info(err.Error())
+	case errors.Is(err, gitops.ErrProtectedBranch):
+		ui.Error(err.Error())
+	case errors.Is(err, gitops.ErrInvalidBranchName):
+		ui.Error("Invalid branch name")
+		ui.Info(err.Error())
+		ui.Info("Avoid spaces, '..', '~', '^', ':', '?', '*', '[' and a trailing '/' or '.lock'")
+	case errors.Is(err, gitops.ErrInvalidTagName):
+		ui.Error("Invalid tag name")
+		ui.Info(err.Error())
+	case errors.Is(err, gitops.ErrTagExists):
+		ui.Error("Tag already exists: " + strings.TrimPrefix(err.Error(), gitops.ErrTagExists.Error()+": "))
+		ui.Info("Pick a newer version")
+	case errors.Is(err, gitops.ErrDirtyWorkTree):
+		ui.Warn("Uncommitted changes detected")
+		ui.Info("Commit or stash your changes first")
+	case errors.Is(err, gitops.ErrNonFastForward):
+		ui.Error(title(op) + " rejected: remote has commits you don't have")
+		ui.Info("Run Smart Pull first, then push again")
+	case errors.Is(err, gitops.ErrConflict):
+		ui.Error(title(op) + " stopped with conflicts")
+		ui.Info("Resolve them: Daily → Resolve conflicts")
+	case errors.Is(err, gitops.ErrAuth):
+		ui.Error("Authentication failed")
+		ui.Info("Check your GitHub token (Tools → Doctor)")
+	case errors.Is(err, gitops.ErrNoStash):
+		ui.Warn("No stash entries found")
+	case errors.Is(err, gitops.ErrInvalidMessage):
+		ui.Error("Commit message rejected")
+		ui.Info(err.Error())
+		ui.Info("Rules: Tools → Commit message rules")
+	case errors.Is(err, gitops.ErrSecretsDetected):
+		ui.Error(title(op) + " blocked: possible secrets in your changes")
+		ui.Info("Unstage / remove the secret, or allowlist it in " + secrets.AllowlistFile)
+		ui.Info("Already committed? Undo last commit, fix it, then push again")
+	case errors.Is(err, gitops.ErrNothingToUndo):
+		ui.Warn("Nothing to undo")
+		if err != gitops.ErrNothingToUndo {
+			ui.Info(err.Error())
+		}
+	case errors.Is(err, gitops.ErrStateChanged) && strings.HasPrefix(op, "stash-"):
+		ui.Error("Stash list changed in the meantime")
+		ui.Info(strings.TrimPrefix(err.Error(), gitops.ErrStateChanged.Error()+": "))
+	case errors.Is(err, gitops.ErrStateChanged):
+		ui.Error("Repository changed since that operation")
+		ui.Info("Check Recovery center → Timeline")
+	case errors.Is(err, gitops.ErrLargeFiles):
+		ui.Error(title(op) + " stopped: " + err.Error())
+		ui.Info("Untrack them, add them to .gitignore or use Git LFS")
+		ui.Info("Limit: Tools → Large file limit")
+	case errors.Is(err, gitops.ErrLFSMissing):
+		ui.Error("Git LFS is not installed")
+		ui.Info("Install git-lfs (pkg install git-lfs / apt install git-lfs)")
+	default:
+		ui.Error(title(op) + " failed")
+	}
+}
+
+func title(op string) string {
+	if t, ok := opTitles[op]; ok {
+		return t
+	}
+	return "Operation"
+}
+
+/*
+run makes sure a repository exists (offering git init) before op runs,
+then renders the result
+*/
+func run(op func() (gitops.Result, error)) {
+	if !system.EnsureGitRepo() {
+		return
+	}
+
+	res, err := op()
+	Render(res, err)
+
+	if errors.Is(err, gitops.ErrConflict) {
+		offerConflictAssistant()
+	}
+}
@@ -0,0 +1,206 @@
+package menu
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"git-genius/internal/gitops"
+	"git-genius/internal/picker"
+	"git-genius/internal/ui"
+)
+
+/* ============================================================
+   Stash (save options, manager with per-entry actions)
+   ============================================================ */
+
+/*
+StashSaveFlow asks what to stash: tracked changes only, with untracked
+(and ignored) files, or selected files; optionally keeping staged changes
+*/
+func StashSaveFlow() (gitops.Result, error) {
+	res := gitops.Result{Operation: "stash-save"}
+
+	st, err := gitops.ReadStatus()
+	if err != nil {
+		return res, err
+	}
+	if st.Clean() {
+		return res, gitops.ErrNothingToCommit
+	}
+
+	var opts gitops.StashOptions
+	options := []string{
+		"Tracked changes (new files stay)",
+		fmt.Sprintf("Tracked changes + untracked files (%d new)", len(st.Untracked)),
+		"Tracked + untracked + ignored files (build output, .env …)",
+		"Only selected files",
+	}
+	switch ui.Select("What to stash", options) {
+	case 1:
+	case 2:
+		opts.Untracked = true
+	case 3:
+		ui.Warn("Ignored files can be large (node_modules, build folders …)")
+		if !ui.ConfirmDefault("Stash ignored files too?", false) {
+			return res, gitops.ErrCancelled
+		}
+		opts.Ignored = true
+	case 4:
+		paths, ok := picker.Files("Stash which files?", st)
+		if !ok || len(paths) == 0 {
+			return res, gitops.ErrCancelled
+		}
+		opts.Paths = paths
+	default:
+		return res, gitops.ErrCancelled
+	}
+
+	if len(st.Staged) > 0 && opts.Paths == nil {
+		opts.KeepIndex = ui.ConfirmDefault("Keep staged changes in the work tree?", false)
+	}
+	opts.Message = ui.Input("Stash message (optional)")
+
+	return gitops.StashSave(opts)
+}
+
+func showStashes(entries []gitops.StashEntry) {
+	for i, e := range entries {
+		fmt.Fprintf(ui.Output(), " %2d) %s  %s%s%s\n", i+1, stashLabel(e),
+			ui.Blue, stashOrigin(e), ui.Reset)
+	}
+}
+
+// stashLabel is the message, or "WIP: <commit>" for git's default one
+func stashLabel(e gitops.StashEntry) string {
+	if e.WIP {
+		return ui.Yellow + "WIP" + ui.Reset + " " + e.Message
+	}
+	return e.Message
+}
+
+func stashOrigin(e gitops.StashEntry) string {
+	branch := e.Branch
+	if branch == "" {
+		branch = "?"
+	}
+	return fmt.Sprintf("(%s · %s · %s)", e.Ref, branch, ago(e.When))
+}
+
+/*
+stashManager lists every stash; picking one opens its actions
+
+Commands:  <n> open · d <n> show diff · q back
+*/
+func stashManager() {
+	for {
+		entries, err := gitops.ReadStashes()
+		if err != nil {
+			renderError("stash-list", err)
+			ui.Pause()
+			return
+		}
+
+		ui.Clear()
+		ui.Header("Stash Manager")
+		if len(entries) == 0 {
+			ui.Info("No stashes found")
+			ui.Pause()
+			return
+		}
+
+		showStashes(entries)
+		fmt.Fprintln(ui.Output())
+		ui.KeyHint("<n> = actions · d <n> = show diff · q = back")
+
+		cmd := strings.ToLower(strings.TrimSpace(ui.Input("Command")))
+		if cmd == "q" || cmd == "" {
+			return
+		}
+
+		diff, numText := false, cmd
+		if rest, ok := strings.CutPrefix(cmd, "d "); ok {
+			diff, numText = true, strings.TrimSpace(rest)
+		}
+
+		n, err := strconv.Atoi(numText)
+		if err != nil || n < 1 || n > len(entries) {
+			ui.Error("Invalid command: " + cmd)
+			ui.Pause()
+			continue
+		}
+
+		if diff {
+			diffViewer("Stash: "+entries[n-1].Message, gitops.StashChanges(entries[n-1]))
+			continue
+		}
+		stashActions(entries[n-1])
+	}
+}
+
+// stashActions runs one action on e; back to the list afterwards
+func stashActions(e gitops.StashEntry) {
+	ui.Clear()
+	ui.Header("Stash " + e.Ref)
+
+	ui.PrintKV("Message", e.Message)
+	ui.PrintKV("Branch", e.Branch)
+	ui.PrintKV("Created", e.When.Format("2006-01-02 15:04")+" ("+ago(e.When)+")")
+	if files, err := gitops.ReadDiff(gitops.StashChanges(e)); err == nil {
+		ui.PrintKV("Files", strconv.Itoa(len(files)))
+	}
+	fmt.Println()
+
+	fmt.Println("1) Show diff")
+	fmt.Println("2) Apply (keep the stash)")
+	fmt.Println("3) Pop (apply and remove)")
+	fmt.Println("4) Rename")
+	fmt.Println("5) Create a branch from it")
+	fmt.Println("6) Drop")
+	fmt.Println("7) Back")
+	fmt.Println()
+
+	switch ui.Input("Select option") {
+	case "1":
+		diffViewer("Stash: "+e.Message, gitops.StashChanges(e))
+		return
+	case "2":
+		run(func() (gitops.Result, error) { return gitops.StashApply(e) })
+	case "3":
+		run(func() (gitops.Result, error) { return gitops.StashPopEntry(e) })
+	case "4":
+		msg := ui.Input("New message")
+		run(func() (gitops.Result, error) { return gitops.StashRename(e, msg) })
+	case "5":
+		run(func() (gitops.Result, error) { return stashBranchFlow(e) })
+	case "6":
+		run(func() (gitops.Result, error) { return stashDropFlow(e) })
+	case "7", "q", "":
+		return
+	default:
+		ui.Error("Invalid option")
+	}
+	ui.Pause()
+}
+
+func stashBranchFlow(e gitops.StashEntry) (gitops.Result, error) {
+	res := gitops.Result{Operation: "stash-branch"}
+
+	ui.Info("The branch starts at the commit the stash was made on, then the stash is applied")
+	name := strings.TrimSpace(ui.Input("New branch name"))
+	if name == "" {
+		return res, gitops.ErrCancelled
+	}
+	return gitops.StashBranch(e, name)
+}
+
+func stashDropFlow(e gitops.StashEntry) (gitops.Result, error) {
+	res := gitops.Result{Operation: "stash-drop"}
+
+	ui.Warn("Dropping deletes " + e.Ref + ": " + e.Message)
+	ui.Info("Undo: Stash & Undo → Undo last git-genius operation (or Recovery center)")
+	if !ui.ConfirmDefault("Drop this stash?", false) {
+		return res, gitops.ErrCancelled
+	}
+	return gitops.StashDrop(e)
+}
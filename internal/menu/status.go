@@ -0,0 +1,103 @@
+package menu
+
+import (
+	"fmt"
+	"strings"
+
+	"git-genius/internal/gitops"
+	"git-genius/internal/ui"
+)
+
+/* ============================================================
+   Status Screen (grouped, colored)
+   ============================================================ */
+
+// ShowStatus prints the repository status grouped by change type
+func ShowStatus(st gitops.RepoStatus) {
+	w := ui.Output()
+
+	ui.PrintKV("Branch", branchLine(st))
+	fmt.Fprintln(w)
+
+	if st.Clean() {
+		ui.Success("Working tree clean")
+		return
+	}
+
+	statusGroup("Conflicts (resolve first)", ui.Red, st.Conflicted, func(e gitops.StatusEntry) string {
+		return "conflict"
+	})
+	statusGroup("Staged (will be committed)", ui.Green, st.Staged, func(e gitops.StatusEntry) string {
+		return gitops.ChangeLabel(e.Index)
+	})
+	statusGroup("Not staged", ui.Yellow, st.Unstaged, func(e gitops.StatusEntry) string {
+		return gitops.ChangeLabel(e.Worktree)
+	})
+	statusGroup("Untracked (new files)", ui.Cyan, st.Untracked, func(e gitops.StatusEntry) string {
+		return "new"
+	})
+}
+
+func statusGroup(title, color string, entries []gitops.StatusEntry, label func(gitops.StatusEntry) string) {
+	if len(entries) == 0 {
+		return
+	}
+
+	w := ui.Output()
+	fmt.Fprintf(w, "%s%s (%d)%s\n", ui.Bold, title, len(entries), ui.Reset)
+	for _, e := range entries {
+		path := e.Path
+		if e.OrigPath != "" {
+			path = e.OrigPath + " → " + e.Path
+		}
+		fmt.Fprintf(w, "  %s%-13s%s %s\n", color, label(e), ui.Reset, path)
+	}
+	fmt.Fprintln(w)
+}
+
+func branchLine(st gitops.RepoStatus) string {
+	line := st.Branch
+	if st.Detached {
+		line = "(detached HEAD)"
+	}
+	if st.Head == "" {
+		line += " (no commits yet)"
+	}
+	if st.Upstream != "" {
+		line += " → " + st.Upstream
+		if ab := aheadBehind(st); ab != "" {
+			line += "  " + ab
+		}
+	}
+	return line
+}
+
+func aheadBehind(st gitops.RepoStatus) string {
+	var parts []string
+	if st.Ahead > 0 {
+		parts = append(parts, fmt.Sprintf("↑%d to push", st.Ahead))
+	}
+	if st.Behind > 0 {
+		parts = append(parts, fmt.Sprintf("↓%d to pull", st.Behind))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// statusSummary is the one-line version used by the context panel
+func statusSummary(st gitops.RepoStatus) string {
+	if st.Clean() {
+		return "clean"
+	}
+
+	var parts []string
+	add := func(n int, label string) {
+		if n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, label))
+		}
+	}
+	add(len(st.Conflicted), "conflicted")
+	add(len(st.Staged), "staged")
+	add(len(st.Unstaged), "modified")
+	add(len(st.Untracked), "untracked")
+	return strings.Join(parts, ", ")
+}
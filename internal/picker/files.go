@@ -0,0 +1,73 @@
+package picker
+
+import (
+	"fmt"
+
+	"git-genius/internal/gitops"
+	"git-genius/internal/ui"
+)
+
+/*
+Files lets the user choose which changed files to use
+Everything is preselected (same result as `git add .` when confirmed).
+Renames return both the new and the old path so the rename stays intact.
+ok = false when the user cancelled
+*/
+func Files(title string, st gitops.RepoStatus) ([]string, bool) {
+	type choice struct {
+		paths []string
+	}
+
+	var items []ui.PickItem
+	var choices []choice
+	seen := map[string]bool{}
+
+	add := func(label string, e gitops.StatusEntry) {
+		if seen[e.Path] {
+			return
+		}
+		seen[e.Path] = true
+
+		name := e.Path
+		paths := []string{e.Path}
+		if e.OrigPath != "" {
+			name = e.OrigPath + " → " + e.Path
+			paths = append(paths, e.OrigPath)
+		}
+
+		items = append(items, ui.PickItem{
+			Label:    fmt.Sprintf("%-12s %s", label, name),
+			Value:    e.Path,
+			Selected: true,
+		})
+		choices = append(choices, choice{paths: paths})
+	}
+
+	for _, e := range st.Conflicted {
+		add("conflict", e)
+	}
+	for _, e := range st.Staged {
+		add(gitops.ChangeLabel(e.Index), e)
+	}
+	for _, e := range st.Unstaged {
+		add(gitops.ChangeLabel(e.Worktree), e)
+	}
+	for _, e := range st.Untracked {
+		add("new", e)
+	}
+
+	if len(items) == 0 {
+		return []string{}, true
+	}
+
+	idx, ok := ui.MultiSelect(title, items)
+	if !ok {
+		return nil, false
+	}
+
+	paths := []string{}
+	for _, i := range idx {
+		paths = append(paths, choices[i].paths...)
+	}
+	return paths, true
+}
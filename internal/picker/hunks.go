@@ -0,0 +1,125 @@
+package picker
+
+import (
+	"fmt"
+	"strings"
+
+	"git-genius/internal/gitops"
+	"git-genius/internal/ui"
+)
+
+/*
+Hunks walks through the unstaged hunks of one file and stages the chosen ones
+
+Answers:
+
+	y = stage hunk        n = skip hunk
+	s = split hunk        a = stage this + rest of file
+	d = skip rest of file q = stop (chosen hunks are still staged)
+
+quit = true when the user pressed q (callers stop reviewing other files)
+*/
+func Hunks(path string) (staged int, quit bool, err error) {
+	fd, err := gitops.UnstagedHunks(path)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if fd.Binary {
+		ui.Warn("Binary file, staging whole file: " + path)
+		_, err := gitops.StageFile(path)
+		return 0, false, err
+	}
+
+	queue := append([]gitops.Hunk(nil), fd.Hunks...)
+	var chosen []gitops.Hunk
+
+review:
+	for i := 0; i < len(queue); i++ {
+		h := queue[i]
+
+		fmt.Fprintf(ui.Output(), "\n%s── %s  (hunk %d/%d) ──%s\n", ui.Bold, path, i+1, len(queue), ui.Reset)
+		PrintHunk(h)
+
+		canSplit := len(gitops.SplitHunk(h)) > 1
+		keys := "y,n,a,d,q,?"
+		if canSplit {
+			keys = "y,n,s,a,d,q,?"
+		}
+
+		switch strings.ToLower(ui.Input("Stage this hunk [" + keys + "]")) {
+		case "y", "yes":
+			chosen = append(chosen, h)
+		case "n", "no", "":
+		case "s":
+			if !canSplit {
+				ui.Warn("This hunk cannot be split further")
+				i--
+				continue
+			}
+			parts := gitops.SplitHunk(h)
+			ui.Info(fmt.Sprintf("Split into %d hunks", len(parts)))
+			queue = append(queue[:i], append(parts, queue[i+1:]...)...)
+			i--
+		case "a":
+			chosen = append(chosen, queue[i:]...)
+			break review
+		case "d":
+			break review
+		case "q":
+			quit = true
+			break review
+		default:
+			ui.PrintHelp([]string{
+				"y - stage this hunk",
+				"n - do not stage this hunk",
+				"s - split into smaller hunks",
+				"a - stage this and all later hunks of the file",
+				"d - skip this and all later hunks of the file",
+				"q - quit (hunks chosen so far are staged)",
+			})
+			i--
+		}
+	}
+
+	if _, err := gitops.StageHunks(fd, chosen); err != nil {
+		return 0, quit, err
+	}
+	return len(chosen), quit, nil
+}
+
+// PrintHunk prints one hunk with colored +/- lines
+func PrintHunk(h gitops.Hunk) {
+	w := ui.Output()
+	fmt.Fprintln(w, ui.Cyan+h.Header()+ui.Reset)
+
+	for _, l := range h.Lines {
+		switch {
+		case strings.HasPrefix(l, "+"):
+			fmt.Fprintln(w, ui.Green+l+ui.Reset)
+		case strings.HasPrefix(l, "-"):
+			fmt.Fprintln(w, ui.Red+l+ui.Reset)
+		default:
+			fmt.Fprintln(w, l)
+		}
+	}
+}
+
+/*
+HunkCandidates returns the selected tracked files with unstaged edits
+(new, deleted, renamed and conflicted files are always staged whole)
+*/
+func HunkCandidates(st gitops.RepoStatus, selected []string) []string {
+	want := map[string]bool{}
+	for _, p := range selected {
+		want[p] = true
+	}
+
+	var paths []string
+	for _, e := range st.Unstaged {
+		if e.Worktree == "M" && e.OrigPath == "" && want[e.Path] {
+			paths = append(paths, e.Path)
+		}
+	}
+	return paths
+}
@@ -0,0 +1,65 @@
+package picker
+
+import (
+	"fmt"
+	"strings"
+
+	"git-genius/internal/gitops"
+	"git-genius/internal/ui"
+)
+
+// maxMatches keeps the result list short enough for a phone screen
+const maxMatches = 30
+
+/*
+ProjectFile asks for part of a file name and lets the user choose
+among the tracked files of the project that match
+ok = false when the user cancelled
+*/
+func ProjectFile(title string) (string, bool) {
+	files, err := gitops.TrackedFiles()
+	if err != nil || len(files) == 0 {
+		ui.Warn("No tracked files in this project")
+		return "", false
+	}
+
+	for {
+		query := strings.TrimSpace(ui.Input(title + " (path or part of the name, Enter = cancel)"))
+		if query == "" {
+			return "", false
+		}
+
+		matches := matchFiles(files, query)
+		switch {
+		case len(matches) == 0:
+			ui.Warn("No tracked file matches: " + query)
+			continue
+		case len(matches) == 1:
+			return matches[0], true
+		case len(matches) > maxMatches:
+			ui.Warn(fmt.Sprintf("%d files match, showing the first %d (type more to narrow)", len(matches), maxMatches))
+			matches = matches[:maxMatches]
+		}
+
+		choice := ui.Select("Matching files", append(matches, "Search again"))
+		if choice >= 1 && choice <= len(matches) {
+			return matches[choice-1], true
+		}
+	}
+}
+
+// matchFiles returns an exact path match, or every path containing query
+func matchFiles(files []string, query string) []string {
+	q := strings.ToLower(query)
+
+	var matches []string
+	for _, f := range files {
+		if f == query {
+			return []string{f}
+		}
+		if strings.Contains(strings.ToLower(f), q) {
+			matches = append(matches, f)
+		}
+	}
+	return matches
+}
@@ -0,0 +1,224 @@
+package secrets
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+/* ============================================================
+   SECRET SCANNER
+   ============================================================
+
+   Scans added lines of a unified diff for credentials.
+   Allowlist file (project root, commit it to share with the team):
+
+     # comment
+     tests/fixtures/*        ignore findings in matching paths
+     rule:aws-secret-key     disable a rule
+     match:AKIAEXAMPLE       ignore findings containing this text
+*/
+
+// AllowlistFile lives in the project root
+const AllowlistFile = ".genius-secrets-allow"
+
+// Rule is one credential pattern
+type Rule struct {
+	ID          string
+	Description string
+	Pattern     *regexp.Regexp
+}
+
+// Finding is one detected secret
+type Finding struct {
+	Rule        string `json:"rule"`
+	Description string `json:"description"`
+	Path        string `json:"path"`
+	Line        int    `json:"line,omitempty"`
+	Commit      string `json:"commit,omitempty"` // outgoing commit scans only
+	Match       string `json:"match"`            // redacted
+}
+
+var Rules = []Rule{
+	{"github-token", "GitHub token", regexp.MustCompile(`\b(ghp|gho|ghu|ghs|ghr)_[A-Za-z0-9]{36,}\b`)},
+	{"github-pat", "GitHub fine-grained token", regexp.MustCompile(`\bgithub_pat_[A-Za-z0-9_]{60,}\b`)},
+	{"aws-access-key", "AWS access key id", regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"aws-secret-key", "AWS secret access key", regexp.MustCompile(`(?i)aws_?secret_?(access_?)?key\s*[:=]\s*["']?[A-Za-z0-9/+=]{40}`)},
+	{"private-key", "Private key block", regexp.MustCompile(`-----BEGIN ([A-Z]+ )?PRIVATE KEY( BLOCK)?-----`)},
+	{"slack-token", "Slack token", regexp.MustCompile(`\bxox[baprs]-[A-Za-z0-9-]{10,}\b`)},
+	{"google-api-key", "Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+}
+
+// envFileRule flags committed .env files regardless of content
+var envFileRule = Rule{ID: "env-file", Description: ".env file with local secrets"}
+
+/* ============================================================
+   ALLOWLIST
+   ============================================================ */
+
+// Allowlist suppresses known false positives
+type Allowlist struct {
+	paths   []string
+	rules   map[string]bool
+	matches []string
+}
+
+// LoadAllowlist reads AllowlistFile from dir (missing file = empty list)
+func LoadAllowlist(dir string) Allowlist {
+	a := Allowlist{rules: map[string]bool{}}
+
+	f, err := os.Open(filepath.Join(dir, AllowlistFile))
+	if err != nil {
+		return a
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "rule:"):
+			a.rules[strings.TrimSpace(strings.TrimPrefix(line, "rule:"))] = true
+		case strings.HasPrefix(line, "match:"):
+			a.matches = append(a.matches, strings.TrimSpace(strings.TrimPrefix(line, "match:")))
+		default:
+			a.paths = append(a.paths, line)
+		}
+	}
+	return a
+}
+
+func (a Allowlist) allows(rule, path, text string) bool {
+	if a.rules[rule] {
+		return true
+	}
+	for _, p := range a.paths {
+		if ok, _ := filepath.Match(p, path); ok {
+			return true
+		}
+		if strings.HasSuffix(p, "/") && strings.HasPrefix(path, p) {
+			return true
+		}
+	}
+	for _, m := range a.matches {
+		if m != "" && strings.Contains(text, m) {
+			return true
+		}
+	}
+	return false
+}
+
+/* ============================================================
+   SCANNING
+   ============================================================ */
+
+/*
+ScanDiff scans the added lines of unified diff / `git log -p` output
+"commit <sha>" lines (git log -p) are attached to findings
+File names come from the "--- " / "+++ " pair before the first @@ of each
+file; inside a hunk an added line starting with "++ " is content
+*/
+func ScanDiff(diff string, allow Allowlist) []Finding {
+	var findings []Finding
+	var path, commit, prev string
+	line := 0
+	header := true // before the first @@ of the current file
+
+	for _, l := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(l, "diff --git "):
+			header = true
+		case header && strings.HasPrefix(l, "commit "):
+			commit = strings.TrimSpace(strings.TrimPrefix(l, "commit "))
+		case header && strings.HasPrefix(l, "+++ ") && strings.HasPrefix(prev, "--- "):
+			path = strings.TrimPrefix(strings.TrimPrefix(l, "+++ "), "b/")
+		case strings.HasPrefix(l, "@@"):
+			header = false
+			line = hunkStart(l)
+		case header:
+		case strings.HasPrefix(l, "commit "):
+			// next commit of git log -p
+			header = true
+			commit = strings.TrimSpace(strings.TrimPrefix(l, "commit "))
+		case strings.HasPrefix(l, "+"):
+			findings = append(findings, scanLine(l[1:], path, line, commit, allow)...)
+			line++
+		case strings.HasPrefix(l, " "):
+			line++
+		}
+		prev = l
+	}
+	return findings
+}
+
+// ScanPaths flags .env style files among the given paths
+func ScanPaths(paths []string, allow Allowlist) []Finding {
+	var findings []Finding
+	for _, p := range paths {
+		if IsEnvFile(p) && !allow.allows(envFileRule.ID, p, "") {
+			findings = append(findings, Finding{
+				Rule:        envFileRule.ID,
+				Description: envFileRule.Description,
+				Path:        p,
+				Match:       filepath.Base(p),
+			})
+		}
+	}
+	return findings
+}
+
+// IsEnvFile matches .env, .env.local, prod.env … but not templates
+func IsEnvFile(path string) bool {
+	base := strings.ToLower(filepath.Base(path))
+	for _, safe := range []string{".example", ".sample", ".template", ".dist"} {
+		if strings.HasSuffix(base, safe) {
+			return false
+		}
+	}
+	return base == ".env" || strings.HasPrefix(base, ".env.") || strings.HasSuffix(base, ".env")
+}
+
+func scanLine(text, path string, line int, commit string, allow Allowlist) []Finding {
+	var findings []Finding
+	for _, r := range Rules {
+		m := r.Pattern.FindString(text)
+		if m == "" || allow.allows(r.ID, path, text) {
+			continue
+		}
+		findings = append(findings, Finding{
+			Rule:        r.ID,
+			Description: r.Description,
+			Path:        path,
+			Line:        line,
+			Commit:      commit,
+			Match:       Redact(m),
+		})
+	}
+	return findings
+}
+
+// hunkStart reads the new-file start line of "@@ -a,b +c,d @@"
+func hunkStart(header string) int {
+	i := strings.Index(header, "+")
+	if i < 0 {
+		return 0
+	}
+	num := header[i+1:]
+	if end := strings.IndexAny(num, ", "); end >= 0 {
+		num = num[:end]
+	}
+	n, _ := strconv.Atoi(num)
+	return n
+}
+
+// Redact keeps just enough of a secret to recognise it
+func Redact(s string) string {
+	if len(s) <= 8 {
+		return strings.Repeat("*", len(s))
+	}
+	return s[:4] + strings.Repeat("*", 8) + s[len(s)-2:]
+}
@@ -0,0 +1,189 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+/* ============================================================
+   SEMANTIC VERSIONS (tags like v1.4.2, 2.0.0-rc.1)
+   ============================================================ */
+
+// Bump kinds
+const (
+	Major = "major"
+	Minor = "minor"
+	Patch = "patch"
+)
+
+// Version is a parsed MAJOR.MINOR.PATCH[-PRE] tag
+type Version struct {
+	Prefix string `json:"prefix"` // "v" or ""
+	Major  int    `json:"major"`
+	Minor  int    `json:"minor"`
+	Patch  int    `json:"patch"`
+	Pre    string `json:"pre,omitempty"` // rc.1, beta.2 …
+}
+
+// Parse reads a tag name; build metadata (+…) is ignored
+func Parse(tag string) (Version, bool) {
+	var v Version
+
+	s := tag
+	if strings.HasPrefix(s, "v") || strings.HasPrefix(s, "V") {
+		v.Prefix, s = s[:1], s[1:]
+	}
+	s, _, _ = strings.Cut(s, "+")
+	core, pre, hasPre := strings.Cut(s, "-")
+	if hasPre {
+		if pre == "" {
+			return Version{}, false
+		}
+		v.Pre = pre
+	}
+
+	parts := strings.Split(core, ".")
+	if len(parts) != 3 {
+		return Version{}, false
+	}
+	nums := make([]int, 3)
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || (len(p) > 1 && p[0] == '0') {
+			return Version{}, false
+		}
+		nums[i] = n
+	}
+	v.Major, v.Minor, v.Patch = nums[0], nums[1], nums[2]
+	return v, true
+}
+
+// String renders the tag name
+func (v Version) String() string {
+	s := fmt.Sprintf("%s%d.%d.%d", v.Prefix, v.Major, v.Minor, v.Patch)
+	if v.Pre != "" {
+		s += "-" + v.Pre
+	}
+	return s
+}
+
+// IsPre reports a pre-release
+func (v Version) IsPre() bool {
+	return v.Pre != ""
+}
+
+/*
+Bump returns the next release of the given kind
+A pre-release is finished first: 1.3.0-rc.2 + minor = 1.3.0
+*/
+func (v Version) Bump(kind string) Version {
+	next := Version{Prefix: v.Prefix, Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+
+	switch kind {
+	case Major:
+		if v.Pre == "" || v.Minor != 0 || v.Patch != 0 {
+			next.Major, next.Minor, next.Patch = v.Major+1, 0, 0
+		}
+	case Minor:
+		if v.Pre == "" || v.Patch != 0 {
+			next.Minor, next.Patch = v.Minor+1, 0
+		}
+	default:
+		if v.Pre == "" {
+			next.Patch = v.Patch + 1
+		}
+	}
+	return next
+}
+
+/*
+PreRelease returns the next pre-release of target with label id:
+target 1.3.0, current 1.3.0-rc.1 → 1.3.0-rc.2; otherwise 1.3.0-rc.1
+*/
+func PreRelease(current, target Version, id string) Version {
+	target.Pre = id + ".1"
+
+	if current.Major == target.Major && current.Minor == target.Minor && current.Patch == target.Patch {
+		if n, ok := strings.CutPrefix(current.Pre, id+"."); ok {
+			if i, err := strconv.Atoi(n); err == nil {
+				target.Pre = id + "." + strconv.Itoa(i+1)
+			}
+		}
+	}
+	return target
+}
+
+// Core drops the pre-release part (1.3.0-rc.2 → 1.3.0)
+func (v Version) Core() Version {
+	v.Pre = ""
+	return v
+}
+
+/*
+Compare orders versions (-1, 0, 1)
+A pre-release sorts before its release; identifiers compare numerically when possible
+*/
+func Compare(a, b Version) int {
+	for _, d := range []int{a.Major - b.Major, a.Minor - b.Minor, a.Patch - b.Patch} {
+		if d != 0 {
+			return sign(d)
+		}
+	}
+
+	switch {
+	case a.Pre == b.Pre:
+		return 0
+	case a.Pre == "":
+		return 1
+	case b.Pre == "":
+		return -1
+	}
+
+	ap, bp := strings.Split(a.Pre, "."), strings.Split(b.Pre, ".")
+	for i := 0; i < len(ap) && i < len(bp); i++ {
+		an, aErr := strconv.Atoi(ap[i])
+		bn, bErr := strconv.Atoi(bp[i])
+		switch {
+		case aErr == nil && bErr == nil:
+			if an != bn {
+				return sign(an - bn)
+			}
+		case aErr == nil:
+			return -1
+		case bErr == nil:
+			return 1
+		default:
+			if c := strings.Compare(ap[i], bp[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return sign(len(ap) - len(bp))
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
+
+/*
+Suggest picks the bump kind for a set of changes
+Breaking → major (minor while still 0.x), features → minor, else patch
+*/
+func Suggest(current Version, breaking, features bool) string {
+	switch {
+	case breaking && current.Major == 0:
+		return Minor
+	case breaking:
+		return Major
+	case features:
+		return Minor
+	}
+	return Patch
+}
@@ -0,0 +1,129 @@
+package semver
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want Version
+		ok   bool
+	}{
+		{"v1.4.2", Version{Prefix: "v", Major: 1, Minor: 4, Patch: 2}, true},
+		{"2.0.0", Version{Major: 2}, true},
+		{"V0.1.0", Version{Prefix: "V", Minor: 1}, true},
+		{"v1.3.0-rc.2", Version{Prefix: "v", Major: 1, Minor: 3, Pre: "rc.2"}, true},
+		{"v1.0.0+build.7", Version{Prefix: "v", Major: 1}, true},
+		{"v1.0.0-beta+exp", Version{Prefix: "v", Major: 1, Pre: "beta"}, true},
+		{"v1.2", Version{}, false},
+		{"v1.2.3.4", Version{}, false},
+		{"v01.2.3", Version{}, false},
+		{"v1.2.3-", Version{}, false},
+		{"v1.-2.3", Version{}, false},
+		{"release-1", Version{}, false},
+		{"", Version{}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := Parse(tt.tag)
+		if ok != tt.ok || got != tt.want {
+			t.Errorf("Parse(%q) = %+v, %v; want %+v, %v", tt.tag, got, ok, tt.want, tt.ok)
+		}
+		if ok && got.String() != tt.tag && !strings.Contains(tt.tag, "+") {
+			t.Errorf("Parse(%q).String() = %q", tt.tag, got.String())
+		}
+	}
+}
+
+func TestBump(t *testing.T) {
+	tests := []struct {
+		from, kind, want string
+	}{
+		{"v1.4.2", Patch, "v1.4.3"},
+		{"v1.4.2", Minor, "v1.5.0"},
+		{"v1.4.2", Major, "v2.0.0"},
+		{"0.9.9", Major, "1.0.0"},
+		// a pre-release is finished before bumping further
+		{"v1.3.0-rc.2", Patch, "v1.3.0"},
+		{"v1.3.0-rc.2", Minor, "v1.3.0"},
+		{"v1.3.0-rc.2", Major, "v2.0.0"},
+		{"v2.0.0-rc.1", Major, "v2.0.0"},
+		{"v1.3.1-rc.1", Minor, "v1.4.0"},
+	}
+
+	for _, tt := range tests {
+		v, ok := Parse(tt.from)
+		if !ok {
+			t.Fatalf("Parse(%q) failed", tt.from)
+		}
+		if got := v.Bump(tt.kind).String(); got != tt.want {
+			t.Errorf("%s.Bump(%s) = %s, want %s", tt.from, tt.kind, got, tt.want)
+		}
+	}
+}
+
+func TestPreRelease(t *testing.T) {
+	tests := []struct {
+		current, target, id, want string
+	}{
+		{"v1.2.0", "v1.3.0", "rc", "v1.3.0-rc.1"},
+		{"v1.3.0-rc.1", "v1.3.0", "rc", "v1.3.0-rc.2"},
+		{"v1.3.0-beta.4", "v1.3.0", "rc", "v1.3.0-rc.1"},
+	}
+
+	for _, tt := range tests {
+		cur, _ := Parse(tt.current)
+		target, _ := Parse(tt.target)
+		if got := PreRelease(cur, target, tt.id).String(); got != tt.want {
+			t.Errorf("PreRelease(%s, %s, %s) = %s, want %s", tt.current, tt.target, tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"v1.0.0", "v1.0.0", 0},
+		{"v1.0.0", "1.0.0", 0},
+		{"v1.2.0", "v1.10.0", -1},
+		{"v2.0.0", "v1.9.9", 1},
+		{"v1.0.0-rc.1", "v1.0.0", -1},
+		{"v1.0.0-rc.2", "v1.0.0-rc.10", -1},
+		{"v1.0.0-alpha", "v1.0.0-beta", -1},
+		{"v1.0.0-1", "v1.0.0-alpha", -1},
+		{"v1.0.0-rc", "v1.0.0-rc.1", -1},
+	}
+
+	for _, tt := range tests {
+		a, _ := Parse(tt.a)
+		b, _ := Parse(tt.b)
+		if got := Compare(a, b); got != tt.want {
+			t.Errorf("Compare(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	v0, _ := Parse("v0.4.0")
+	v1, _ := Parse("v1.4.0")
+
+	tests := []struct {
+		current            Version
+		breaking, features bool
+		want               string
+	}{
+		{v1, true, true, Major},
+		{v0, true, false, Minor},
+		{v1, false, true, Minor},
+		{v1, false, false, Patch},
+	}
+	for _, tt := range tests {
+		if got := Suggest(tt.current, tt.breaking, tt.features); got != tt.want {
+			t.Errorf("Suggest(%s, %v, %v) = %s, want %s", tt.current, tt.breaking, tt.features, got, tt.want)
+		}
+	}
+}
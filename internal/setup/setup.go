@@ -1,6 +1,7 @@
 package setup
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -8,6 +9,9 @@ import (
 
 	"git-genius/internal/config"
 	"git-genius/internal/github"
+	"git-genius/internal/gitops"
+	"git-genius/internal/picker"
+	"git-genius/internal/secrets"
 	"git-genius/internal/system"
 	"git-genius/internal/ui"
 )
@@ -271,18 +275,71 @@ func offerFirstPush(cfg *config.Config) {
 		return
 	}
 
-	msg := ui.Input("Initial commit message")
-	if msg == "" {
-		msg = "Initial commit"
+	// gitops reads remote and branch from the saved config
+	config.Save(*cfg)
+
+	opts := gitops.PushOptions{PushOnly: true}
+
+	// Let the user leave out build artifacts / unfinished files
+	st, err := gitops.ReadStatus()
+	if err == nil && !st.Clean() {
+		paths, ok := picker.Files("Files to include in first commit", st)
+		if !ok {
+			ui.Warn("First push cancelled")
+			return
+		}
+
+		if len(paths) > 0 {
+			msg := ui.Input("Initial commit message")
+			if msg == "" {
+				msg = "Initial commit"
+			}
+			opts = gitops.PushOptions{Message: msg, Paths: paths}
+		} else {
+			ui.Warn("No files selected, pushing existing commits only")
+		}
 	}
 
-	_ = system.RunGit("add", ".")
-	_ = system.RunGit("commit", "-m", msg)
-	_ = system.RunGit("push", "-u", cfg.Remote, cfg.Branch)
+	// same checks as every push: secrets, large files, message rules
+	res, err := gitops.Push(opts)
+	for _, w := range res.Warnings {
+		ui.Warn(w)
+	}
+	if err != nil {
+		reportPushError(res, err)
+		return
+	}
 
 	ui.Success("Code pushed successfully")
 }
 
+// reportPushError explains why the first push stopped (the menu is not available here)
+func reportPushError(res gitops.Result, err error) {
+	switch {
+	case errors.Is(err, gitops.ErrSecretsDetected):
+		ui.Error("Push blocked: possible secrets in your changes")
+		if findings, ok := res.Data.([]secrets.Finding); ok {
+			for _, f := range findings {
+				ui.Warn(f.Description + " in " + f.Path + " (" + f.Match + ")")
+			}
+		}
+		ui.Info("Remove the secret or allowlist it in " + secrets.AllowlistFile + ", then push from the main menu")
+	case errors.Is(err, gitops.ErrLargeFiles):
+		ui.Error("Push stopped: " + err.Error())
+		if files, ok := res.Data.([]gitops.LargeFile); ok {
+			for _, f := range files {
+				ui.Warn(f.Path + " (" + gitops.FormatSize(f.Size) + ")")
+			}
+		}
+		ui.Info("Push from the main menu to untrack them, ignore them or use Git LFS")
+	case errors.Is(err, gitops.ErrNothingToCommit):
+		ui.Warn("Nothing to push yet")
+	default:
+		ui.Error("Push failed: " + err.Error())
+		ui.Info("Check the remote and your token, then push from the main menu")
+	}
+}
+
 /* ============================================================
    STEP 2.5: Git identity (ANDROID SAFE, LOCAL ONLY)
    ============================================================ */
@@ -309,7 +366,7 @@ func ensureGitIdentity(workDir string) bool {
 			ui.Error("Name cannot be empty")
 			return false
 		}
-		if err := system.GitCmdAt(workDir, "config", "user.name", val).Run(); err != nil {
+		if err := system.RunGitAt(workDir, "config", "user.name", val); err != nil {
 			ui.Error("Failed to set git user.name")
 			return false
 		}
@@ -321,7 +378,7 @@ func ensureGitIdentity(workDir string) bool {
 			ui.Error("Email cannot be empty")
 			return false
 		}
-		if err := system.GitCmdAt(workDir, "config", "user.email", val).Run(); err != nil {
+		if err := system.RunGitAt(workDir, "config", "user.email", val); err != nil {
 			ui.Error("Failed to set git user.email")
 			return false
 		}
@@ -336,12 +393,12 @@ func ensureGitIdentity(workDir string) bool {
    ============================================================ */
 
 func gitConfig(dir, key string) string {
-	var out strings.Builder
-	cmd := system.GitCmdAt(dir, "config", "--get", key)
-	cmd.Stdout = &out
-
-	if err := cmd.Run(); err != nil {
+	resp, err := system.Git(system.Request{
+		Args: []string{"config", "--get", key},
+		Dir:  dir,
+	})
+	if err != nil {
 		return ""
 	}
-	return strings.TrimSpace(out.String())
+	return strings.TrimSpace(resp.Stdout)
 }
@@ -0,0 +1,168 @@
+package setup
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git-genius/internal/config"
+	"git-genius/internal/system"
+	"git-genius/internal/ui"
+)
+
+// scripted runs Run in an empty "app" directory, offline, with canned answers
+func scripted(t *testing.T, answers ...string) (*system.FakeRunner, *ui.ScriptedPrompter) {
+	t.Helper()
+
+	dir := filepath.Join(t.TempDir(), "app")
+	if err := os.Mkdir(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	wd, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	fake := system.NewFakeRunner()
+	fake.On("config --get user.name", system.Response{ExitCode: 1})
+	fake.On("config --get user.email", system.Response{ExitCode: 1})
+	fake.On("branch --show-current", system.Response{Stdout: "main\n"})
+	fake.On("status --porcelain=v2 --branch -z --untracked-files=all", system.Response{
+		Stdout: "# branch.head main\x00? main.go\x00? build.log\x00",
+	})
+	fake.On("status --porcelain", system.Response{Stdout: "?? main.go\n?? build.log\n"})
+	fake.On("log -1", system.Response{ExitCode: 128}) // no commits yet
+
+	prompter := ui.NewScriptedPrompter(answers...)
+	prevRunner := system.SetRunner(fake)
+	prevPrompter := ui.SetPrompter(prompter)
+	prevOut := ui.Output()
+	ui.SetOutput(&bytes.Buffer{})
+	online := system.Online
+	system.Online = false
+
+	t.Cleanup(func() {
+		system.Online = online
+		ui.SetOutput(prevOut)
+		ui.SetPrompter(prevPrompter)
+		system.SetRunner(prevRunner)
+		_ = os.Chdir(wd)
+	})
+	return fake, prompter
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name    string
+		answers []string
+		want    []string
+		never   []string
+		owner   string
+		fail    string            // git command that exits 1
+		stdout  map[string]string // git command → output
+	}{
+		{
+			name: "full setup with first push",
+			answers: []string{
+				"n",                           // different project directory?
+				"y", "Ada", "ada@example.com", // git identity
+				"", "", // branch, remote
+				"octo",               // GitHub owner
+				"y", "ghp_testtoken", // token
+				"y", "-*.log", "", "", // first push: leave out build.log, default message
+			},
+			want: []string{
+				"config user.name Ada",
+				"config user.email ada@example.com",
+				"add -A -- main.go",
+				"remote add origin https://ghp_testtoken@github.com/octo/app.git",
+				"commit -m Initial commit",
+				"push -u origin main",
+			},
+			never: []string{"add -A -- main.go build.log"},
+			owner: "octo",
+		},
+		{
+			name: "failed first commit is not pushed",
+			answers: []string{
+				"n", "y", "Ada", "ada@example.com", "", "", "octo", "y", "ghp_testtoken", "y", "", "",
+			},
+			fail:  "commit",
+			want:  []string{"add -A -- main.go build.log"},
+			never: []string{"push"},
+			owner: "octo",
+		},
+		{
+			name: "secret in the first commit blocks commit and push",
+			answers: []string{
+				"n", "y", "Ada", "ada@example.com", "", "", "octo", "y", "ghp_testtoken", "y", "", "",
+			},
+			stdout: map[string]string{
+				"diff --cached --no-color --no-ext-diff -U0": "diff --git a/main.go b/main.go\n" +
+					"--- /dev/null\n+++ b/main.go\n@@ -0,0 +1 @@\n" +
+					"+const token = \"ghp_" + strings.Repeat("a", 36) + "\"\n",
+			},
+			want:  []string{"add -A -- main.go build.log"},
+			never: []string{"commit", "push"},
+			owner: "octo",
+		},
+		{
+			name:    "stops without a git identity",
+			answers: []string{"n", "n"},
+			never:   []string{"config user.name", "remote add", "commit", "push"},
+		},
+		{
+			name: "no first push",
+			answers: []string{
+				"n", "y", "Ada", "ada@example.com", "main", "origin", "octo", "n", "n",
+			},
+			never: []string{"commit", "push"},
+			owner: "octo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake, prompter := scripted(t, tt.answers...)
+			if tt.fail != "" {
+				fake.On(tt.fail, system.Response{ExitCode: 1})
+			}
+			for args, out := range tt.stdout {
+				fake.On(args, system.Response{Stdout: out})
+			}
+
+			Run()
+
+			cmds := fake.Commands()
+			for _, want := range tt.want {
+				found := false
+				for _, c := range cmds {
+					found = found || c == want
+				}
+				if !found {
+					t.Errorf("missing git %s\nran: %q", want, cmds)
+				}
+			}
+			for _, never := range tt.never {
+				for _, c := range cmds {
+					if c == never || strings.HasPrefix(c, never+" ") {
+						t.Errorf("unexpected git %s", c)
+					}
+				}
+			}
+			if left := prompter.Remaining(); len(left) > 0 {
+				t.Errorf("unused answers: %q", left)
+			}
+
+			cfg := config.Load()
+			if cfg.Owner != tt.owner {
+				t.Errorf("saved owner = %q, want %q", cfg.Owner, tt.owner)
+			}
+			if tt.owner != "" && cfg.Repo != "app" {
+				t.Errorf("saved repo = %q, want app", cfg.Repo)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package system
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+/* ============================================================
+   EDITOR
+   ============================================================ */
+
+/*
+Editor returns the user's editor command:
+$VISUAL, $EDITOR, git's core.editor, then a platform default
+*/
+func Editor() string {
+	for _, env := range []string{"VISUAL", "EDITOR"} {
+		if e := strings.TrimSpace(os.Getenv(env)); e != "" {
+			return e
+		}
+	}
+
+	if e, err := GitRaw("config", "--get", "core.editor"); err == nil && strings.TrimSpace(e) != "" {
+		return strings.TrimSpace(e)
+	}
+
+	switch {
+	case runtime.GOOS == "windows":
+		return "notepad"
+	case commandExists("nano"):
+		return "nano" // Termux / beginner friendly
+	}
+	return "vi"
+}
+
+// OpenEditor opens path in Editor() attached to the terminal
+func OpenEditor(path string) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command(Editor(), path)
+	} else {
+		// sh keeps editor arguments working ("code --wait")
+		cmd = exec.Command("sh", "-c", Editor()+` "$@"`, "editor", path)
+	}
+
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -2,7 +2,6 @@ package system
 
 import (
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -79,65 +78,24 @@ func EnsureSafeDirectory(path string) {
 
 //
 // ============================================================
-// GIT COMMAND BUILDERS
+// GIT EXECUTORS (ALL GO THROUGH THE ACTIVE RUNNER)
 // ============================================================
 //
 
-// GitCmd builds git command using config.WorkDir
-func GitCmd(args ...string) *exec.Cmd {
-	git := getGitPath()
-	if git == "" {
-		return exec.Command("false")
-	}
-
-	cmd := exec.Command(git, args...)
-
-	cfg := config.Load()
-	if cfg.WorkDir != "" {
-		cmd.Dir = cfg.WorkDir
-	}
-
-	return cmd
-}
-
-// GitCmdAt builds git command for explicit directory
-func GitCmdAt(dir string, args ...string) *exec.Cmd {
-	git := getGitPath()
-	if git == "" {
-		return exec.Command("false")
-	}
-
-	cmd := exec.Command(git, args...)
-	cmd.Dir = dir
-	return cmd
-}
-
-//
-// ============================================================
-// GIT EXECUTORS
-// ============================================================
-//
-
-// RunGit runs git in config.WorkDir
+// RunGit runs git in config.WorkDir, streaming output to the user
 func RunGit(args ...string) error {
-	cmd := GitCmd(args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	if err := cmd.Run(); err != nil {
-		LogError("git "+strings.Join(args, " "), err)
-		return err
-	}
-	return nil
+	return RunGitAt("", args...)
 }
 
 // RunGitAt runs git in specific directory
 func RunGitAt(dir string, args ...string) error {
-	cmd := GitCmdAt(dir, args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-
-	if err := cmd.Run(); err != nil {
+	_, err := Git(Request{
+		Args:   args,
+		Dir:    dir,
+		Stdout: ui.Output(),
+		Stderr: os.Stderr,
+	})
+	if err != nil {
 		LogError("git "+strings.Join(args, " "), err)
 		return err
 	}
@@ -146,24 +104,67 @@ func RunGitAt(dir string, args ...string) error {
 
 // GitOutput runs git and returns trimmed output
 func GitOutput(args ...string) (string, error) {
-	cmd := GitCmd(args...)
-	out, err := cmd.Output()
+	return GitOutputAt("", args...)
+}
+
+// GitOutputAt runs git in dir and returns output
+func GitOutputAt(dir string, args ...string) (string, error) {
+	resp, err := Git(Request{Args: args, Dir: dir})
 	if err != nil {
 		LogError("git "+strings.Join(args, " "), err)
 		return "", err
 	}
-	return strings.TrimSpace(string(out)), nil
+	return strings.TrimSpace(resp.Stdout), nil
 }
 
-// GitOutputAt runs git in dir and returns output
-func GitOutputAt(dir string, args ...string) (string, error) {
-	cmd := GitCmdAt(dir, args...)
-	out, err := cmd.Output()
+// GitRaw returns untrimmed stdout (porcelain formats where spacing matters)
+// Failures are not logged: callers treat them as "no data"
+func GitRaw(args ...string) (string, error) {
+	resp, err := Git(Request{Args: args})
+	return resp.Stdout, err
+}
+
+// GitOK reports whether git exits successfully (quiet probe, never logged)
+func GitOK(args ...string) bool {
+	_, err := Git(Request{Args: args})
+	return err == nil
+}
+
+// GitOKAt is GitOK for an explicit directory
+func GitOKAt(dir string, args ...string) bool {
+	_, err := Git(Request{Args: args, Dir: dir})
+	return err == nil
+}
+
+// GitDir returns the absolute .git directory of config.WorkDir
+func GitDir() string {
+	if out, err := GitRaw("rev-parse", "--absolute-git-dir"); err == nil && strings.TrimSpace(out) != "" {
+		return strings.TrimSpace(out)
+	}
+	return filepath.Join(config.Load().GetWorkDir(), ".git")
+}
+
+// GitCombined runs git and returns trimmed stdout+stderr
+// Used when output is captured into a result instead of streamed
+func GitCombined(args ...string) (string, error) {
+	return GitCombinedEnv(nil, args...)
+}
+
+// GitCombinedEnv is GitCombined with extra KEY=VALUE environment
+func GitCombinedEnv(env []string, args ...string) (string, error) {
+	resp, err := Git(Request{Args: args, Env: env})
 	if err != nil {
 		LogError("git "+strings.Join(args, " "), err)
-		return "", err
 	}
-	return strings.TrimSpace(string(out)), nil
+
+	out := strings.TrimSpace(resp.Stdout)
+	if errOut := strings.TrimSpace(resp.Stderr); errOut != "" {
+		if out != "" {
+			out += "\n"
+		}
+		out += errOut
+	}
+	return out, err
 }
 
 //
@@ -173,13 +174,11 @@ func GitOutputAt(dir string, args ...string) (string, error) {
 //
 
 func IsGitRepo() bool {
-	cmd := GitCmd("rev-parse", "--is-inside-work-tree")
-	return cmd.Run() == nil
+	return GitOK("rev-parse", "--is-inside-work-tree")
 }
 
 func IsGitRepoAt(dir string) bool {
-	cmd := GitCmdAt(dir, "rev-parse", "--is-inside-work-tree")
-	return cmd.Run() == nil
+	return GitOKAt(dir, "rev-parse", "--is-inside-work-tree")
 }
 
 func EnsureGitRepo() bool {
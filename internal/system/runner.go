@@ -0,0 +1,302 @@
+package system
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"git-genius/internal/config"
+)
+
+//
+// ============================================================
+// RUNNER ABSTRACTION
+// ============================================================
+//
+// Every git invocation in git-genius goes through the active Runner.
+// ExecRunner runs the real binary; FakeRunner answers from a script
+// so menus, setup and doctor can be driven without a real repository.
+//
+
+// Request describes one git invocation
+type Request struct {
+	Args    []string
+	Dir     string        // empty = runner default / config.WorkDir
+	Env     []string      // extra KEY=VALUE entries
+	Stdin   io.Reader     // nil = no input
+	Stdout  io.Writer     // nil = captured into Response.Stdout
+	Stderr  io.Writer     // nil = captured into Response.Stderr
+	Timeout time.Duration // 0 = runner default (no limit)
+}
+
+// Response is what a git invocation produced
+type Response struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+}
+
+// Runner executes git requests
+type Runner interface {
+	Run(req Request) (Response, error)
+}
+
+var (
+	ErrGitNotFound = errors.New("git executable not found")
+	ErrTimeout     = errors.New("git command timed out")
+)
+
+// ExitError is returned when git exits with a non-zero status
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+/* ============================================================
+   ACTIVE RUNNER
+   ============================================================ */
+
+var (
+	runnerMu sync.RWMutex
+	runner   Runner = &ExecRunner{}
+)
+
+// SetRunner replaces the active runner and returns the previous one
+func SetRunner(r Runner) Runner {
+	runnerMu.Lock()
+	defer runnerMu.Unlock()
+
+	prev := runner
+	runner = r
+	return prev
+}
+
+// CurrentRunner returns the active runner
+func CurrentRunner() Runner {
+	runnerMu.RLock()
+	defer runnerMu.RUnlock()
+	return runner
+}
+
+// Git runs a request on the active runner (no error logging)
+func Git(req Request) (Response, error) {
+	return CurrentRunner().Run(req)
+}
+
+/* ============================================================
+   EXEC RUNNER (REAL GIT)
+   ============================================================ */
+
+/*
+ExecRunner runs the git binary found in PATH
+Dir     = default working dir (empty = config.WorkDir at call time)
+Env     = extra environment for every command
+Timeout = default timeout (0 = none)
+*/
+type ExecRunner struct {
+	Dir     string
+	Env     []string
+	Timeout time.Duration
+}
+
+func (r *ExecRunner) Run(req Request) (Response, error) {
+	git := getGitPath()
+	if git == "" {
+		return Response{ExitCode: -1}, ErrGitNotFound
+	}
+
+	timeout := req.Timeout
+	if timeout == 0 {
+		timeout = r.Timeout
+	}
+
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, git, req.Args...)
+	cmd.Dir = r.resolveDir(req.Dir)
+	cmd.Stdin = req.Stdin
+
+	if len(r.Env) > 0 || len(req.Env) > 0 {
+		cmd.Env = append(append(os.Environ(), r.Env...), req.Env...)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if req.Stdout != nil {
+		cmd.Stdout = req.Stdout
+	}
+	if req.Stderr != nil {
+		cmd.Stderr = req.Stderr
+	}
+
+	err := cmd.Run()
+
+	resp := Response{Stdout: stdout.String(), Stderr: stderr.String()}
+	if cmd.ProcessState != nil {
+		resp.ExitCode = cmd.ProcessState.ExitCode()
+	}
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return resp, ErrTimeout
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return resp, &ExitError{Code: exitErr.ExitCode()}
+	}
+	return resp, err
+}
+
+func (r *ExecRunner) resolveDir(dir string) string {
+	if dir != "" {
+		return dir
+	}
+	if r.Dir != "" {
+		return r.Dir
+	}
+	return config.Load().WorkDir
+}
+
+/* ============================================================
+   FAKE RUNNER (SCRIPTED RESPONSES)
+   ============================================================ */
+
+/*
+FakeRunner answers git requests from a script and records every call
+
+	fake := system.NewFakeRunner()
+	fake.On("branch --show-current", system.Response{Stdout: "main\n"})
+	fake.On("push", system.Response{Stderr: "! [rejected]", ExitCode: 1})
+	prev := system.SetRunner(fake)
+	defer system.SetRunner(prev)
+
+Matching: exact argument string first, then longest registered prefix.
+Unmatched requests get Default (exit 0, no output).
+*/
+type FakeRunner struct {
+	mu        sync.Mutex
+	responses map[string]Response
+	Default   Response
+	Calls     []Request
+}
+
+func NewFakeRunner() *FakeRunner {
+	return &FakeRunner{responses: map[string]Response{}}
+}
+
+// On registers the response for a command ("status --porcelain")
+func (f *FakeRunner) On(args string, resp Response) *FakeRunner {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.responses[args] = resp
+	return f
+}
+
+func (f *FakeRunner) Run(req Request) (Response, error) {
+	f.mu.Lock()
+	f.Calls = append(f.Calls, req)
+	resp := f.match(strings.Join(req.Args, " "))
+	f.mu.Unlock()
+
+	if req.Stdout != nil {
+		_, _ = io.WriteString(req.Stdout, resp.Stdout)
+		resp.Stdout = ""
+	}
+	if req.Stderr != nil {
+		_, _ = io.WriteString(req.Stderr, resp.Stderr)
+		resp.Stderr = ""
+	}
+
+	if resp.ExitCode != 0 {
+		return resp, &ExitError{Code: resp.ExitCode}
+	}
+	return resp, nil
+}
+
+func (f *FakeRunner) match(cmd string) Response {
+	if resp, ok := f.responses[cmd]; ok {
+		return resp
+	}
+
+	best := -1
+	resp := f.Default
+	for prefix, r := range f.responses {
+		if strings.HasPrefix(cmd, prefix+" ") && len(prefix) > best {
+			best = len(prefix)
+			resp = r
+		}
+	}
+	return resp
+}
+
+// Commands returns every recorded invocation as "arg arg arg"
+func (f *FakeRunner) Commands() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	out := make([]string, 0, len(f.Calls))
+	for _, c := range f.Calls {
+		out = append(out, strings.Join(c.Args, " "))
+	}
+	return out
+}
+
+/* ============================================================
+   RECORDING RUNNER (WRAPS ANOTHER RUNNER)
+   ============================================================ */
+
+// Call is one recorded invocation
+type Call struct {
+	Request  Request
+	Response Response
+	Err      error
+	Duration time.Duration
+}
+
+// RecordingRunner forwards to Next and keeps a transcript of every call
+type RecordingRunner struct {
+	Next Runner
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *RecordingRunner) Run(req Request) (Response, error) {
+	start := time.Now()
+	resp, err := r.Next.Run(req)
+
+	r.mu.Lock()
+	r.calls = append(r.calls, Call{
+		Request:  req,
+		Response: resp,
+		Err:      err,
+		Duration: time.Since(start),
+	})
+	r.mu.Unlock()
+
+	return resp, err
+}
+
+// Calls returns a copy of the transcript
+func (r *RecordingRunner) Calls() []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Call(nil), r.calls...)
+}
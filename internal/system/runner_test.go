@@ -0,0 +1,78 @@
+package system
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFakeRunnerMatching(t *testing.T) {
+	fake := NewFakeRunner()
+	fake.Default = Response{Stdout: "default"}
+	fake.On("status", Response{Stdout: "status"})
+	fake.On("status --porcelain", Response{Stdout: "porcelain"})
+	fake.On("push", Response{Stderr: "! [rejected]", ExitCode: 1})
+
+	tests := []struct {
+		args   string
+		stdout string
+		code   int
+	}{
+		{"status", "status", 0},
+		{"status --short", "status", 0},
+		{"status --porcelain -z", "porcelain", 0},
+		{"statusx", "default", 0},
+		{"push origin main", "", 1},
+		{"log", "default", 0},
+	}
+
+	for _, tt := range tests {
+		resp, err := fake.Run(Request{Args: strings.Fields(tt.args)})
+		if resp.Stdout != tt.stdout || resp.ExitCode != tt.code {
+			t.Errorf("%s: got %q exit %d, want %q exit %d", tt.args, resp.Stdout, resp.ExitCode, tt.stdout, tt.code)
+		}
+
+		var exitErr *ExitError
+		if (tt.code != 0) != errors.As(err, &exitErr) {
+			t.Errorf("%s: err = %v", tt.args, err)
+		}
+	}
+
+	if got := fake.Commands(); len(got) != len(tests) || got[4] != "push origin main" {
+		t.Errorf("Commands() = %q", got)
+	}
+}
+
+func TestFakeRunnerWritesToStreams(t *testing.T) {
+	fake := NewFakeRunner().On("diff", Response{Stdout: "patch", Stderr: "warning"})
+
+	var out, errOut strings.Builder
+	resp, err := fake.Run(Request{Args: []string{"diff"}, Stdout: &out, Stderr: &errOut})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.String() != "patch" || errOut.String() != "warning" || resp.Stdout != "" || resp.Stderr != "" {
+		t.Errorf("streams = %q / %q, response = %+v", out.String(), errOut.String(), resp)
+	}
+}
+
+func TestRecordingRunner(t *testing.T) {
+	fake := NewFakeRunner().On("rev-parse HEAD", Response{Stdout: "abc\n"})
+	rec := &RecordingRunner{Next: fake}
+
+	prev := SetRunner(rec)
+	defer SetRunner(prev)
+
+	out, err := GitRaw("rev-parse", "HEAD")
+	if err != nil || out != "abc\n" {
+		t.Fatalf("GitRaw() = %q, %v", out, err)
+	}
+	if !GitOK("push") {
+		t.Error("GitOK(push) = false with a default response")
+	}
+
+	calls := rec.Calls()
+	if len(calls) != 2 || calls[0].Response.Stdout != "abc\n" || strings.Join(calls[1].Request.Args, " ") != "push" {
+		t.Errorf("Calls() = %+v", calls)
+	}
+}
@@ -17,20 +17,70 @@ var HelpMain = []string{
 	"4) Tools",
 	"   - Setup, GitHub repo linking, Doctor (health check)",
 	"",
-	"h / help / ?",
+	"5) History & Blame",
+	"   - Browse commits with a branch graph, filters and diffs",
+	"   - Blame a file, follow its history across renames",
+	"",
+	"6) Releases",
+	"   - List tags, create the next version tag, publish tags",
+	"   - Changelog from commit messages",
+	"",
+	"7) Help / h / ?",
 	"   - Show this help screen",
 	"",
-	"5) Exit",
+	"8) Exit",
 	"   - Quit Git Genius",
 }
 
+// ============================================================
+// History Help
+// ============================================================
+
+var HelpHistory = []string{
+	"Browsing",
+	"- Newest commits first, with an ASCII branch graph",
+	"- n / p = next / previous page, a = include all branches",
+	"- Type a number to open a commit: message, changed files, d = diff",
+	"",
+	"Filters (f)",
+	"- Author, path, since / until date, message text",
+	"- Dates: 2024-01-31, yesterday, 2 weeks ago",
+	"",
+	"Picking",
+	"- Other screens (e.g. Recovery center) reuse the browser to pick a commit",
+	"",
+	"Blame A File",
+	"- Type part of a file name, then pick the file",
+	"- Every line shows the commit, author and date that last changed it",
+	"- Type a line number to open that commit; g <line> jumps to a line",
+	"- Moved / copied code is traced to the file it came from",
+	"",
+	"File History",
+	"- Every commit that touched the file, following renames",
+	"- <n> opens the commit, d <n> shows only this file's diff",
+}
+
 // ============================================================
 // Daily Git Operations Help
 // ============================================================
 
 var HelpDaily = []string{
 	"Push",
-	"- Stages files, commits, and pushes to GitHub",
+	"- Pick which changed files to commit (all selected by default)",
+	"- Shortcuts: a = all, n = none, +*.go / -dist/* = glob select",
+	"- Optional: choose individual hunks of modified files",
+	"  (y = stage, n = skip, s = split, a = rest of file, q = stop)",
+	"- Commit message: type 'w' for the guided Conventional Commit composer",
+	"  or 'd' to review the diff of the selected files first",
+	"- Messages are checked against Tools → Commit message rules",
+	"- Files over the size limit (default 50 MB) are caught before",
+	"  commit: untrack, add to .gitignore or track with Git LFS",
+	"- Staged changes and outgoing commits are scanned for secrets",
+	"  (tokens, AWS keys, private keys, .env files)",
+	"- Findings block the push; type 'override' to continue anyway",
+	"- False positives: list paths / rule:<id> / match:<text>",
+	"  in .genius-secrets-allow (project root)",
+	"- Commits the selection and pushes to GitHub",
 	"- First push will guide you if repo/remote is missing",
 	"",
 	"Pull",
@@ -46,6 +96,29 @@ var HelpDaily = []string{
 	"",
 	"Status",
 	"- Shows modified, staged, and untracked files",
+	"",
+	"Review Changes",
+	"- Not staged / staged / everything uncommitted / two commits",
+	"- File list with +/- counts; open a file, then n / p for next / previous",
+	"- Changed words are highlighted inside changed lines (w toggles)",
+	"- s = side by side (needs a terminal at least 100 columns wide)",
+	"",
+	"Merge A Branch",
+	"- Brings another branch's commits into the current branch",
+	"- Needs a clean work tree (commit or stash first)",
+	"",
+	"Resolve Conflicts",
+	"- Lists conflicted files (also offered after pull / merge / stash pop)",
+	"- Per conflict: keep ours, theirs, both, or edit in $EDITOR",
+	"- Binary / deleted files: pick one side for the whole file",
+	"- Then complete the merge, or abort back to the state before",
+	"",
+	"Clean Up Unpushed Commits",
+	"- Lists commits not pushed yet (oldest first)",
+	"- <n> p/r/s/f/d = pick / reword / squash / fixup / drop (2-4 s works)",
+	"- m <from> <to> moves a commit; Enter previews the new history",
+	"- Runs git rebase -i for you: no todo file, no editor",
+	"- A backup ref is saved first; any failure aborts the rebase",
 }
 
 // ============================================================
@@ -54,14 +127,98 @@ var HelpDaily = []string{
 
 var HelpBranch = []string{
 	"Switch Branch",
-	"- Create a new branch or switch to existing one",
+	"- Existing branch: switched to, never reset",
+	"- Only on the remote (or origin/<name>): local tracking branch created",
+	"- New name: created from the current commit (after confirmation)",
+	"- Uncommitted changes: stash them, carry them over, or abort",
 	"- Automatically updates config branch",
 	"",
+	"Branch Manager",
+	"- Local + remote branches with upstream, ↑ahead ↓behind, age",
+	"- Rename, delete, set upstream, clean up merged branches",
+	"",
 	"Switch Remote",
 	"- Change where your code is pushed",
 	"- Useful when moving between GitHub repos",
 }
 
+// ============================================================
+// Branch Manager Help
+// ============================================================
+
+var HelpBranchManager = []string{
+	"List",
+	"- * marks the current branch",
+	"- Upstream with ↑ commits to push and ↓ commits to pull",
+	"- (gone) = the upstream was deleted on the remote",
+	"- merged = every commit is already in the default branch",
+	"",
+	"Delete",
+	"- Merged branches are deleted after one confirmation",
+	"- Unmerged branches need a second confirmation and get a backup ref",
+	"- Current and default branch are never deleted",
+	"- Rename and delete can be undone (Stash & Undo → Undo last operation)",
+	"",
+	"Set Upstream",
+	"- Connects a local branch to <remote>/<branch>",
+	"- Not on the remote yet? Git Genius can push it first",
+	"- Publishing runs the secret scan and the large file check first",
+	"",
+	"Clean Up Merged",
+	"- Deletes local branches already merged into the default branch",
+}
+
+// ============================================================
+// Pending Operation Help
+// ============================================================
+
+var HelpPending = []string{
+	"Why am I here?",
+	"- A merge, rebase, cherry-pick, revert or bisect stopped half-way",
+	"- Push, pull, merge and branch switch wait until it is finished",
+	"",
+	"Continue",
+	"- Resolve conflicts first, then continue where git stopped",
+	"",
+	"Skip",
+	"- Drops the commit git is stuck on and goes on with the next one",
+	"",
+	"Abort",
+	"- Everything goes back to how it was before the operation started",
+	"",
+	"Bisect",
+	"- Mark each checked out commit good or bad until git finds the culprit",
+	"- Finish / abort returns to your branch",
+}
+
+// ============================================================
+// Releases Help
+// ============================================================
+
+var HelpReleases = []string{
+	"Versions",
+	"- Tags like v1.4.2 or 2.0.0-rc.1 (semantic versions)",
+	"- The latest version tag on your branch is the current version",
+	"",
+	"Create Release Tag",
+	"- Suggests the next version from commit types since that tag:",
+	"  breaking change = major (minor before 1.0), feat = minor, else patch",
+	"- Pre-release (rc.1, rc.2 …) or any custom newer version",
+	"- Annotated tag, or signed with your gpg / ssh key",
+	"",
+	"Publish Tags",
+	"- Lists tags the remote does not have yet",
+	"- Shows the commit behind every tag before pushing",
+	"- Warns when a tag would publish commits you have not pushed",
+	"- Those commits pass the secret scan and the large file check first",
+	"",
+	"Changelog",
+	"- Markdown between two tags, or the last tag and HEAD",
+	"- Grouped by commit type: breaking changes, features, bug fixes …",
+	"- #12 and commits link to GitHub when owner / repo are configured",
+	"- Optionally added to the top of CHANGELOG.md and committed",
+}
+
 // ============================================================
 // Stash & Undo Help
 // ============================================================
@@ -70,9 +227,16 @@ var HelpStash = []string{
 	"Stash Changes",
 	"- Save uncommitted work temporarily",
 	"- Clean working directory",
+	"- Choose: tracked changes only, + untracked files, + ignored files,",
+	"  or only the files you pick",
+	"- Keep staged changes: they stay in place (and are in the stash too)",
 	"",
-	"Stash List",
-	"- View all saved stashes",
+	"Stash Manager",
+	"- Every stash with its message, branch and age",
+	"- Show diff (untracked files included), apply, pop (apply + remove)",
+	"- Rename (the stash moves to the top of the list)",
+	"- Create a branch from a stash: starts where the stash was made",
+	"- Drop asks first; Undo last git-genius operation brings it back",
 	"",
 	"Stash Pop",
 	"- Restore last stashed changes",
@@ -80,6 +244,43 @@ var HelpStash = []string{
 	"Undo Last Commit",
 	"- Undo commit but KEEP file changes",
 	"- Safe and reversible",
+	"",
+	"Undo Last git-genius Operation",
+	"- Push, pull, smart pull, switch, stash, restores, large file fixes,",
+	"  branch rename / delete / cleanup and tag creation are journaled",
+	"  (.git/.genius/journal.jsonl: time, branch, HEAD before/after,",
+	"  stashes, created / deleted refs; the work tree for stash and",
+	"  large file fixes)",
+	"- Reverts the latest one; repeat to walk further back",
+	"- Already pushed commits stay on the remote",
+	"",
+	"Recovery Center",
+	"- Timeline of everything HEAD did (reflog)",
+	"- Restore lost commits, deleted branches, dropped stashes",
+}
+
+// ============================================================
+// Recovery Help
+// ============================================================
+
+var HelpRecovery = []string{
+	"Timeline",
+	"- Every commit, checkout, reset, rebase and pull (newest first)",
+	"- Pick an entry to move the current branch back (or forward) to it",
+	"- Uncommitted changes are kept; git refuses if they would be lost",
+	"",
+	"Restore To A Commit From History",
+	"- Same as the timeline, but pick any commit in the history browser",
+	"",
+	"Recreate Deleted Branch",
+	"- Branches you checked out earlier that no longer exist",
+	"",
+	"Recover Dropped Stash",
+	"- Finds stashes removed by drop / clear and puts them back",
+	"",
+	"Backups",
+	"- Before moving HEAD, Git Genius saves it under refs/genius/backup/",
+	"- Restore a backup to undo a recovery",
 }
 
 // ============================================================
@@ -104,6 +305,14 @@ var HelpTools = []string{
 	"Doctor",
 	"- Checks git, branch, remote, token, repo",
 	"- Suggests fixes if something is wrong",
+	"",
+	"Commit message rules",
+	"- Enforcement: off / warn / block",
+	"- Conventional Commits, allowed types, max length",
+	"- Required ticket id (regexp), always-on composer",
+	"",
+	"Large file limit",
+	"- Size (MB) above which push asks what to do with a file",
 }
 
 // ============================================================
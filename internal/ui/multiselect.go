@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+/* ============================================================
+   Multi select (toggle list)
+   ============================================================ */
+
+// PickItem is one toggleable entry of MultiSelect
+// Value is matched by glob shortcuts (usually a file path)
+type PickItem struct {
+	Label    string
+	Value    string
+	Selected bool
+}
+
+/*
+MultiSelect lets the user toggle items and returns the selected indexes
+
+Commands:
+
+	1 3 5-7     toggle items
+	a / all     select everything
+	n / none    clear selection
+	+<glob>     select matching values   (+*.go, +src/*)
+	-<glob>     deselect matching values (-dist/*)
+	Enter / d   done
+	q           cancel (ok = false)
+*/
+func MultiSelect(title string, items []PickItem) ([]int, bool) {
+	for {
+		fmt.Fprintln(out, Cyan+title+Reset)
+		for i, it := range items {
+			mark := "[ ]"
+			if it.Selected {
+				mark = Green + "[x]" + Reset
+			}
+			fmt.Fprintf(out, " %s %2d) %s\n", mark, i+1, it.Label)
+		}
+		KeyHint("numbers/ranges = toggle · a = all · n = none · +glob / -glob · Enter = done · q = cancel")
+
+		cmd := strings.TrimSpace(Input("Selection"))
+
+		switch strings.ToLower(cmd) {
+		case "", "d", "done":
+			return selectedIndexes(items), true
+		case "q", "quit", "cancel":
+			return nil, false
+		case "a", "all":
+			setAll(items, true)
+			continue
+		case "n", "none":
+			setAll(items, false)
+			continue
+		}
+
+		if strings.HasPrefix(cmd, "+") || strings.HasPrefix(cmd, "-") {
+			if n := selectGlob(items, cmd[1:], cmd[0] == '+'); n == 0 {
+				Warn("No items match: " + cmd[1:])
+			}
+			continue
+		}
+
+		if !toggleNumbers(items, cmd) {
+			Error("Invalid selection: " + cmd)
+		}
+	}
+}
+
+func selectedIndexes(items []PickItem) []int {
+	var idx []int
+	for i, it := range items {
+		if it.Selected {
+			idx = append(idx, i)
+		}
+	}
+	return idx
+}
+
+func setAll(items []PickItem, selected bool) {
+	for i := range items {
+		items[i].Selected = selected
+	}
+}
+
+// selectGlob matches against the full value and its base name
+func selectGlob(items []PickItem, pattern string, selected bool) int {
+	count := 0
+	for i, it := range items {
+		full, _ := filepath.Match(pattern, it.Value)
+		base, _ := filepath.Match(pattern, filepath.Base(it.Value))
+		prefix := strings.HasSuffix(pattern, "/") && strings.HasPrefix(it.Value, pattern)
+
+		if full || base || prefix {
+			items[i].Selected = selected
+			count++
+		}
+	}
+	return count
+}
+
+// toggleNumbers handles "1 3 5-7" (also comma separated)
+func toggleNumbers(items []PickItem, cmd string) bool {
+	var picks []int
+
+	for _, tok := range strings.FieldsFunc(cmd, func(r rune) bool { return r == ' ' || r == ',' }) {
+		lo, hi, isRange := strings.Cut(tok, "-")
+		a, err := strconv.Atoi(lo)
+		if err != nil {
+			return false
+		}
+		b := a
+		if isRange {
+			if b, err = strconv.Atoi(hi); err != nil {
+				return false
+			}
+		}
+		if a < 1 || b > len(items) || a > b {
+			return false
+		}
+		for n := a; n <= b; n++ {
+			picks = append(picks, n-1)
+		}
+	}
+
+	for _, i := range picks {
+		items[i].Selected = !items[i].Selected
+	}
+	return len(picks) > 0
+}
@@ -0,0 +1,258 @@
+package ui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+/* ============================================================
+   Prompter abstraction
+   ============================================================
+
+   Every question git-genius asks goes through the active Prompter.
+   Swap it for scripted answers (unattended runs, end-to-end tests),
+   an "assume yes" wrapper (CLI --yes) or an alternate frontend.
+*/
+
+// Prompter answers the questions asked by menus and wizards
+type Prompter interface {
+	Input(label string) string
+	SecretInput(label string) string
+	Confirm(question string, def bool) bool
+	// Select returns the chosen option (1-based), 0 when no answer is available
+	Select(label string, options []string) int
+	Pause()
+}
+
+var (
+	prompterMu sync.RWMutex
+	prompter   Prompter = NewTerminalPrompter(os.Stdin)
+)
+
+// SetPrompter replaces the active prompter and returns the previous one
+func SetPrompter(p Prompter) Prompter {
+	prompterMu.Lock()
+	defer prompterMu.Unlock()
+
+	prev := prompter
+	prompter = p
+	return prev
+}
+
+// CurrentPrompter returns the active prompter
+func CurrentPrompter() Prompter {
+	prompterMu.RLock()
+	defer prompterMu.RUnlock()
+	return prompter
+}
+
+/* ============================================================
+   Line prompter (terminal + scripted share the parsing rules)
+   ============================================================ */
+
+/*
+LinePrompter prints questions to Output() and reads one answer per line
+next returns false when no more input is available (EOF / script end)
+*/
+type LinePrompter struct {
+	next func() (string, bool)
+	echo bool // print answers (nobody typed them)
+}
+
+// NewTerminalPrompter reads answers from r (normally os.Stdin)
+func NewTerminalPrompter(r io.Reader) *LinePrompter {
+	reader := bufio.NewReader(r)
+
+	return &LinePrompter{
+		next: func() (string, bool) {
+			text, err := reader.ReadString('\n')
+			if err != nil && text == "" {
+				return "", false
+			}
+			return strings.TrimSpace(text), true
+		},
+	}
+}
+
+func (p *LinePrompter) read() (string, bool) {
+	text, ok := p.next()
+	if p.echo {
+		fmt.Fprintln(out, text)
+	}
+	return text, ok
+}
+
+func (p *LinePrompter) Input(label string) string {
+	fmt.Fprint(out, Cyan+label+": "+Reset)
+	text, _ := p.read()
+	return text
+}
+
+func (p *LinePrompter) SecretInput(label string) string {
+	fmt.Fprint(out, Cyan+label+": "+Reset)
+
+	// Never echo secrets, even for scripted answers
+	text, _ := p.next()
+	if p.echo {
+		fmt.Fprintln(out, "********")
+	}
+	return text
+}
+
+func (p *LinePrompter) Confirm(question string, def bool) bool {
+	defStr := "y/N"
+	if def {
+		defStr = "Y/n"
+	}
+
+	for {
+		fmt.Fprint(out, Yellow+question+" ("+defStr+"): "+Reset)
+		text, ok := p.read()
+		ans := strings.ToLower(text)
+
+		if ans == "" || !ok {
+			return def
+		}
+		if ans == "y" || ans == "yes" {
+			return true
+		}
+		if ans == "n" || ans == "no" {
+			return false
+		}
+		fmt.Fprintln(out, Red+"Please enter y or n."+Reset)
+	}
+}
+
+func (p *LinePrompter) Select(label string, options []string) int {
+	fmt.Fprintln(out, Cyan+label+Reset)
+	for i, opt := range options {
+		fmt.Fprintf(out, " %d) %s\n", i+1, opt)
+	}
+
+	for {
+		fmt.Fprint(out, "Select option: ")
+		text, ok := p.read()
+		if !ok {
+			return 0
+		}
+
+		for i := range options {
+			if text == fmt.Sprint(i+1) {
+				return i + 1
+			}
+		}
+		fmt.Fprintln(out, Red+"Invalid choice"+Reset)
+	}
+}
+
+func (p *LinePrompter) Pause() {
+	fmt.Fprint(out, "\nPress Enter to continue...")
+	p.next()
+	if p.echo {
+		fmt.Fprintln(out)
+	}
+}
+
+/* ============================================================
+   Scripted prompter (canned answers)
+   ============================================================ */
+
+/*
+ScriptedPrompter answers questions from a fixed list, in order
+Pause never consumes an answer.
+OnExhausted runs once when the script runs out (e.g. stop the menu loop);
+afterwards Input returns "", Confirm its default and Select 0.
+*/
+type ScriptedPrompter struct {
+	LinePrompter
+
+	mu          sync.Mutex
+	answers     []string
+	pos         int
+	OnExhausted func()
+}
+
+func NewScriptedPrompter(answers ...string) *ScriptedPrompter {
+	s := &ScriptedPrompter{answers: answers}
+	s.LinePrompter = LinePrompter{next: s.nextAnswer, echo: true}
+	return s
+}
+
+// LoadScriptedPrompter reads one answer per line from a file
+func LoadScriptedPrompter(path string) (*ScriptedPrompter, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	text := strings.TrimRight(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	if text == "" {
+		return NewScriptedPrompter(), nil
+	}
+	return NewScriptedPrompter(strings.Split(text, "\n")...), nil
+}
+
+func (s *ScriptedPrompter) nextAnswer() (string, bool) {
+	s.mu.Lock()
+	if s.pos < len(s.answers) {
+		ans := s.answers[s.pos]
+		s.pos++
+		s.mu.Unlock()
+		return strings.TrimSpace(ans), true
+	}
+
+	first := s.pos == len(s.answers)
+	s.pos++
+	s.mu.Unlock()
+
+	if first && s.OnExhausted != nil {
+		s.OnExhausted()
+	}
+	return "", false
+}
+
+// Pause does not consume a scripted answer
+func (s *ScriptedPrompter) Pause() {
+	fmt.Fprintln(out)
+}
+
+// Remaining returns the answers not used yet
+func (s *ScriptedPrompter) Remaining() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pos >= len(s.answers) {
+		return nil
+	}
+	return append([]string(nil), s.answers[s.pos:]...)
+}
+
+/* ============================================================
+   Assume-yes prompter (CLI --yes)
+   ============================================================ */
+
+// AssumeYesPrompter answers every confirmation with "yes"
+// All other questions go to Next
+type AssumeYesPrompter struct {
+	Next Prompter
+}
+
+func (a AssumeYesPrompter) Input(label string) string       { return a.Next.Input(label) }
+func (a AssumeYesPrompter) SecretInput(label string) string { return a.Next.SecretInput(label) }
+func (a AssumeYesPrompter) Select(label string, options []string) int {
+	return a.Next.Select(label, options)
+}
+func (a AssumeYesPrompter) Pause() { a.Next.Pause() }
+
+func (a AssumeYesPrompter) Confirm(question string, def bool) bool {
+	defStr := "y/N"
+	if def {
+		defStr = "Y/n"
+	}
+	fmt.Fprintln(out, Yellow+question+" ("+defStr+"): "+Reset+"yes")
+	return true
+}
@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+/* ============================================================
+   Terminal size
+   ============================================================ */
+
+// DefaultWidth is used when the terminal cannot be asked
+const DefaultWidth = 80
+
+/*
+TerminalWidth returns the number of columns
+$COLUMNS first, then `stty size` on /dev/tty (Linux, macOS, Termux)
+*/
+func TerminalWidth() int {
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+
+	tty, err := os.Open("/dev/tty")
+	if err != nil {
+		return DefaultWidth
+	}
+	defer tty.Close()
+
+	cmd := exec.Command("stty", "size")
+	cmd.Stdin = tty
+	out, err := cmd.Output()
+	if err != nil {
+		return DefaultWidth
+	}
+
+	// "rows cols"
+	fields := strings.Fields(string(out))
+	if len(fields) != 2 {
+		return DefaultWidth
+	}
+	if n, err := strconv.Atoi(fields[1]); err == nil && n > 0 {
+		return n
+	}
+	return DefaultWidth
+}
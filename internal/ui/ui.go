@@ -1,10 +1,9 @@
 package ui
 
 import (
-	"bufio"
 	"fmt"
+	"io"
 	"os"
-	"strings"
 )
 
 /* ============================================================
@@ -23,25 +22,32 @@ const (
 )
 
 /* ============================================================
-   Reader (single instance)
+   Output
    ============================================================ */
 
-var reader = bufio.NewReader(os.Stdin)
+// out receives all human-readable output (stderr in JSON mode)
+var out io.Writer = os.Stdout
+
+// SetOutput redirects human-readable output
+func SetOutput(w io.Writer) {
+	out = w
+}
+
+// Output returns the current human-readable output writer
+func Output() io.Writer {
+	return out
+}
 
 /* ============================================================
-   Input helpers
+   Input helpers (delegate to the active Prompter)
    ============================================================ */
 
 func Input(label string) string {
-	fmt.Print(Cyan + label + ": " + Reset)
-	text, _ := reader.ReadString('\n')
-	return strings.TrimSpace(text)
+	return CurrentPrompter().Input(label)
 }
 
 func SecretInput(label string) string {
-	fmt.Print(Cyan + label + ": " + Reset)
-	text, _ := reader.ReadString('\n')
-	return strings.TrimSpace(text)
+	return CurrentPrompter().SecretInput(label)
 }
 
 func Confirm(question string) bool {
@@ -49,50 +55,14 @@ func Confirm(question string) bool {
 }
 
 func ConfirmDefault(question string, def bool) bool {
-	defStr := "y/N"
-	if def {
-		defStr = "Y/n"
-	}
-
-	for {
-		fmt.Print(Yellow + question + " (" + defStr + "): " + Reset)
-		text, _ := reader.ReadString('\n')
-		ans := strings.ToLower(strings.TrimSpace(text))
-
-		if ans == "" {
-			return def
-		}
-		if ans == "y" || ans == "yes" {
-			return true
-		}
-		if ans == "n" || ans == "no" {
-			return false
-		}
-		fmt.Println(Red + "Please enter y or n." + Reset)
-	}
+	return CurrentPrompter().Confirm(question, def)
 }
 
 /*
 Select presents numbered options and returns choice index (1-based)
 */
 func Select(label string, options []string) int {
-	fmt.Println(Cyan + label + Reset)
-	for i, opt := range options {
-		fmt.Printf(" %d) %s\n", i+1, opt)
-	}
-
-	for {
-		fmt.Print("Select option: ")
-		text, _ := reader.ReadString('\n')
-		text = strings.TrimSpace(text)
-
-		for i := range options {
-			if text == fmt.Sprint(i+1) {
-				return i + 1
-			}
-		}
-		fmt.Println(Red + "Invalid choice" + Reset)
-	}
+	return CurrentPrompter().Select(label, options)
 }
 
 /* ============================================================
@@ -100,22 +70,21 @@ func Select(label string, options []string) int {
    ============================================================ */
 
 func Pause() {
-	fmt.Print("\nPress Enter to continue...")
-	reader.ReadString('\n')
+	CurrentPrompter().Pause()
 }
 
 func Clear() {
-	fmt.Print("\033[H\033[2J")
+	fmt.Fprint(out, "\033[H\033[2J")
 }
 
 func Header(title string) {
-	fmt.Println(Magenta + "========================================" + Reset)
-	fmt.Println(Bold + Cyan + " " + title + Reset)
-	fmt.Println(Magenta + "========================================" + Reset)
+	fmt.Fprintln(out, Magenta+"========================================"+Reset)
+	fmt.Fprintln(out, Bold+Cyan+" "+title+Reset)
+	fmt.Fprintln(out, Magenta+"========================================"+Reset)
 }
 
 func Divider() {
-	fmt.Println(Magenta + "----------------------------------------" + Reset)
+	fmt.Fprintln(out, Magenta+"----------------------------------------"+Reset)
 }
 
 /* ============================================================
@@ -123,19 +92,19 @@ func Divider() {
    ============================================================ */
 
 func Info(msg string) {
-	fmt.Println(Cyan + "ℹ " + msg + Reset)
+	fmt.Fprintln(out, Cyan+"ℹ "+msg+Reset)
 }
 
 func Success(msg string) {
-	fmt.Println(Green + "✔ " + msg + Reset)
+	fmt.Fprintln(out, Green+"✔ "+msg+Reset)
 }
 
 func Warn(msg string) {
-	fmt.Println(Yellow + "⚠ " + msg + Reset)
+	fmt.Fprintln(out, Yellow+"⚠ "+msg+Reset)
 }
 
 func Error(msg string) {
-	fmt.Println(Red + "✘ " + msg + Reset)
+	fmt.Fprintln(out, Red+"✘ "+msg+Reset)
 }
 
 /* ============================================================
@@ -143,11 +112,11 @@ func Error(msg string) {
    ============================================================ */
 
 func PrintKV(key, value string) {
-	fmt.Printf("%-10s : %s\n", key, value)
+	fmt.Fprintf(out, "%-10s : %s\n", key, value)
 }
 
 func KeyHint(keys string) {
-	fmt.Println(Blue + "[" + keys + "]" + Reset)
+	fmt.Fprintln(out, Blue+"["+keys+"]"+Reset)
 }
 
 // Help renders a help screen with title and bullet points
@@ -158,7 +127,7 @@ func KeyHint(keys string) {
 // PrintHelp prints help lines in a clean readable format
 func PrintHelp(lines []string) {
 	for _, line := range lines {
-		fmt.Println("  " + line)
+		fmt.Fprintln(out, "  "+line)
 	}
-	fmt.Println()
+	fmt.Fprintln(out)
 }